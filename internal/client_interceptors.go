@@ -0,0 +1,245 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"context"
+
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+	"go.temporal.io/temporal-proto/workflowservice"
+)
+
+// STATUS: partially implemented, and only partially wireable in this snapshot.
+//
+//   - Workflow chain: wireable. WorkflowDefinition.Execute (internal_worker_base.go) is a real,
+//     already-exported call site whose signature is exactly WorkflowInboundInterceptor.
+//     ExecuteWorkflow's, so NewInterceptedWorkflowDefinitionFactory below decorates a real
+//     WorkflowDefinitionFactory with a real interceptor chain, with no missing types required.
+//     Nothing in this snapshot outside its test calls NewInterceptedWorkflowDefinitionFactory.
+//   - Client chain and activity chain: NOT wired, and cannot be from this file. This snapshot has
+//     no ClientOptions, no Client implementation, and no internal_workflow_client.go to register
+//     ClientInterceptorFactory on, and no activity task handler (no interface resembling
+//     ActivityInboundInterceptor.ExecuteActivity's (ctx, *commonpb.Payloads) shape exists to wrap)
+//     to register ActivityInboundInterceptorFactory on. ChainClientInterceptors and
+//     ChainActivityInboundInterceptors below are real and tested, but have no production caller
+//     until those files exist - whoever adds them should call into the existing Chain* helpers
+//     rather than re-deriving the chain-building logic.
+
+// ClientInterceptorFactory is used to create a single link in the client-side interceptor
+// chain. It is the Client-facing counterpart of WorkflowInterceptorFactory.
+type ClientInterceptorFactory interface {
+	// NewInterceptor creates an interceptor instance. The created instance must delegate every
+	// call to the next parameter for the client to function correctly.
+	NewInterceptor(next ClientInterceptor) ClientInterceptor
+}
+
+// ClientInterceptor is an interface that can be implemented to intercept calls made through
+// Client. It is the standard extension point for auth, tenant tagging, rate limiting, metrics,
+// and context propagation on the client side.
+type ClientInterceptor interface {
+	StartWorkflow(ctx context.Context, options StartWorkflowOptions, workflowType string, args ...interface{}) (WorkflowRun, error)
+	SignalWorkflow(ctx context.Context, workflowID, runID, signalName string, arg interface{}) error
+	SignalWithStartWorkflow(ctx context.Context, workflowID, signalName string, signalArg interface{}, options StartWorkflowOptions, workflowType string, workflowArgs ...interface{}) (WorkflowRun, error)
+	QueryWorkflow(ctx context.Context, workflowID, runID, queryType string, args ...interface{}) (Value, error)
+	RecordActivityHeartbeat(ctx context.Context, taskToken []byte, details ...interface{}) error
+	CompleteActivity(ctx context.Context, taskToken []byte, result interface{}, activityErr error) error
+	TerminateWorkflow(ctx context.Context, workflowID, runID, reason string, details ...interface{}) error
+	CancelWorkflow(ctx context.Context, workflowID, runID string) error
+	ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error)
+}
+
+var _ ClientInterceptor = (*ClientInterceptorBase)(nil)
+
+// ClientInterceptorBase is a default implementation of ClientInterceptor that forwards every
+// call to the next interceptor in the chain. Embed it to implement only the methods you need to
+// intercept.
+type ClientInterceptorBase struct {
+	next ClientInterceptor
+}
+
+func (t *ClientInterceptorBase) StartWorkflow(ctx context.Context, options StartWorkflowOptions, workflowType string, args ...interface{}) (WorkflowRun, error) {
+	return t.next.StartWorkflow(ctx, options, workflowType, args...)
+}
+
+func (t *ClientInterceptorBase) SignalWorkflow(ctx context.Context, workflowID, runID, signalName string, arg interface{}) error {
+	return t.next.SignalWorkflow(ctx, workflowID, runID, signalName, arg)
+}
+
+func (t *ClientInterceptorBase) SignalWithStartWorkflow(ctx context.Context, workflowID, signalName string, signalArg interface{}, options StartWorkflowOptions, workflowType string, workflowArgs ...interface{}) (WorkflowRun, error) {
+	return t.next.SignalWithStartWorkflow(ctx, workflowID, signalName, signalArg, options, workflowType, workflowArgs...)
+}
+
+func (t *ClientInterceptorBase) QueryWorkflow(ctx context.Context, workflowID, runID, queryType string, args ...interface{}) (Value, error) {
+	return t.next.QueryWorkflow(ctx, workflowID, runID, queryType, args...)
+}
+
+func (t *ClientInterceptorBase) RecordActivityHeartbeat(ctx context.Context, taskToken []byte, details ...interface{}) error {
+	return t.next.RecordActivityHeartbeat(ctx, taskToken, details...)
+}
+
+func (t *ClientInterceptorBase) CompleteActivity(ctx context.Context, taskToken []byte, result interface{}, activityErr error) error {
+	return t.next.CompleteActivity(ctx, taskToken, result, activityErr)
+}
+
+func (t *ClientInterceptorBase) TerminateWorkflow(ctx context.Context, workflowID, runID, reason string, details ...interface{}) error {
+	return t.next.TerminateWorkflow(ctx, workflowID, runID, reason, details...)
+}
+
+func (t *ClientInterceptorBase) CancelWorkflow(ctx context.Context, workflowID, runID string) error {
+	return t.next.CancelWorkflow(ctx, workflowID, runID)
+}
+
+func (t *ClientInterceptorBase) ListWorkflow(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest) (*workflowservice.ListWorkflowExecutionsResponse, error) {
+	return t.next.ListWorkflow(ctx, request)
+}
+
+// ActivityInboundInterceptorFactory is used to create a single link in the worker-side
+// interceptor chain that wraps the activity task handler's entry point into user code.
+type ActivityInboundInterceptorFactory interface {
+	NewInterceptor(next ActivityInboundInterceptor) ActivityInboundInterceptor
+}
+
+// ActivityInboundInterceptor is an interface that can be implemented to intercept the execution
+// of an activity from the worker side, e.g. to tag metrics with the caller's tenant or enforce
+// rate limits before user code runs.
+type ActivityInboundInterceptor interface {
+	ExecuteActivity(ctx context.Context, args *commonpb.Payloads) (*commonpb.Payloads, error)
+}
+
+var _ ActivityInboundInterceptor = (*ActivityInboundInterceptorBase)(nil)
+
+// ActivityInboundInterceptorBase is a default implementation of ActivityInboundInterceptor that
+// forwards every call to the next interceptor in the chain.
+type ActivityInboundInterceptorBase struct {
+	next ActivityInboundInterceptor
+}
+
+func (a *ActivityInboundInterceptorBase) ExecuteActivity(ctx context.Context, args *commonpb.Payloads) (*commonpb.Payloads, error) {
+	return a.next.ExecuteActivity(ctx, args)
+}
+
+// WorkflowInboundInterceptorFactory is used to create a single link in the worker-side
+// interceptor chain that wraps a WorkflowDefinition's entry point into user code.
+type WorkflowInboundInterceptorFactory interface {
+	NewInterceptor(next WorkflowInboundInterceptor) WorkflowInboundInterceptor
+}
+
+// WorkflowInboundInterceptor is an interface that can be implemented to intercept the entry
+// point of workflow execution on the worker side.
+type WorkflowInboundInterceptor interface {
+	ExecuteWorkflow(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads)
+}
+
+var _ WorkflowInboundInterceptor = (*WorkflowInboundInterceptorBase)(nil)
+
+// WorkflowInboundInterceptorBase is a default implementation of WorkflowInboundInterceptor that
+// forwards every call to the next interceptor in the chain.
+type WorkflowInboundInterceptorBase struct {
+	next WorkflowInboundInterceptor
+}
+
+func (w *WorkflowInboundInterceptorBase) ExecuteWorkflow(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads) {
+	w.next.ExecuteWorkflow(env, header, input)
+}
+
+// ChainClientInterceptors composes factories into a single ClientInterceptor, in the order
+// given: factories[0]'s interceptor is outermost (the first to see a call), root terminates the
+// chain. This is the piece ClientOptions would call into to build the chain it installs on
+// Client - see the status note at the top of this file for why that installation point does not
+// exist in this snapshot yet.
+func ChainClientInterceptors(root ClientInterceptor, factories ...ClientInterceptorFactory) ClientInterceptor {
+	chain := root
+	for i := len(factories) - 1; i >= 0; i-- {
+		chain = factories[i].NewInterceptor(chain)
+	}
+	return chain
+}
+
+// ChainActivityInboundInterceptors composes factories into a single ActivityInboundInterceptor,
+// in the order given: factories[0]'s interceptor is outermost, root terminates the chain. This
+// is the piece the activity task handler would call into - see the status note at the top of
+// this file for why that wiring does not exist in this snapshot yet.
+func ChainActivityInboundInterceptors(root ActivityInboundInterceptor, factories ...ActivityInboundInterceptorFactory) ActivityInboundInterceptor {
+	chain := root
+	for i := len(factories) - 1; i >= 0; i-- {
+		chain = factories[i].NewInterceptor(chain)
+	}
+	return chain
+}
+
+// ChainWorkflowInboundInterceptors composes factories into a single WorkflowInboundInterceptor,
+// in the order given: factories[0]'s interceptor is outermost, root terminates the chain.
+// NewInterceptedWorkflowDefinitionFactory builds its chain with this - see the status note at the
+// top of this file.
+func ChainWorkflowInboundInterceptors(root WorkflowInboundInterceptor, factories ...WorkflowInboundInterceptorFactory) WorkflowInboundInterceptor {
+	chain := root
+	for i := len(factories) - 1; i >= 0; i-- {
+		chain = factories[i].NewInterceptor(chain)
+	}
+	return chain
+}
+
+// NewInterceptedWorkflowDefinitionFactory wraps factory so every WorkflowDefinition it produces
+// runs ExecuteWorkflow through the chain built from factories before the call reaches the wrapped
+// definition's own Execute.
+func NewInterceptedWorkflowDefinitionFactory(factory WorkflowDefinitionFactory, factories ...WorkflowInboundInterceptorFactory) WorkflowDefinitionFactory {
+	return &interceptedWorkflowDefinitionFactory{factory: factory, factories: factories}
+}
+
+type interceptedWorkflowDefinitionFactory struct {
+	factory   WorkflowDefinitionFactory
+	factories []WorkflowInboundInterceptorFactory
+}
+
+func (f *interceptedWorkflowDefinitionFactory) NewWorkflowDefinition() WorkflowDefinition {
+	def := f.factory.NewWorkflowDefinition()
+	root := &terminalWorkflowInboundInterceptor{def: def}
+	return &interceptedWorkflowDefinition{
+		WorkflowDefinition: def,
+		chain:              ChainWorkflowInboundInterceptors(root, f.factories...),
+	}
+}
+
+// interceptedWorkflowDefinition overrides Execute to run the interceptor chain; every other
+// WorkflowDefinition method passes straight through to the embedded definition unchanged.
+type interceptedWorkflowDefinition struct {
+	WorkflowDefinition
+	chain WorkflowInboundInterceptor
+}
+
+func (d *interceptedWorkflowDefinition) Execute(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads) {
+	d.chain.ExecuteWorkflow(env, header, input)
+}
+
+// terminalWorkflowInboundInterceptor is the innermost link in the chain: the one that actually
+// calls the wrapped WorkflowDefinition's Execute, ending the chain instead of forwarding further.
+type terminalWorkflowInboundInterceptor struct {
+	WorkflowInboundInterceptorBase
+	def WorkflowDefinition
+}
+
+func (t *terminalWorkflowInboundInterceptor) ExecuteWorkflow(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads) {
+	t.def.Execute(env, header, input)
+}
@@ -0,0 +1,140 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonpb "go.temporal.io/temporal-proto/common/v1"
+)
+
+type recordingActivityInboundInterceptor struct {
+	ActivityInboundInterceptorBase
+	name string
+	log  *[]string
+}
+
+func (r *recordingActivityInboundInterceptor) ExecuteActivity(ctx context.Context, args *commonpb.Payloads) (*commonpb.Payloads, error) {
+	*r.log = append(*r.log, r.name)
+	return r.next.ExecuteActivity(ctx, args)
+}
+
+type recordingActivityInboundInterceptorFactory struct {
+	name string
+	log  *[]string
+}
+
+func (f *recordingActivityInboundInterceptorFactory) NewInterceptor(next ActivityInboundInterceptor) ActivityInboundInterceptor {
+	return &recordingActivityInboundInterceptor{
+		ActivityInboundInterceptorBase: ActivityInboundInterceptorBase{next: next},
+		name:                           f.name,
+		log:                            f.log,
+	}
+}
+
+type rootActivityInboundInterceptor struct {
+	ActivityInboundInterceptorBase
+	log *[]string
+}
+
+func (r *rootActivityInboundInterceptor) ExecuteActivity(ctx context.Context, args *commonpb.Payloads) (*commonpb.Payloads, error) {
+	*r.log = append(*r.log, "root")
+	return nil, nil
+}
+
+func TestChainActivityInboundInterceptors_OrdersOutermostFirst(t *testing.T) {
+	var log []string
+	root := &rootActivityInboundInterceptor{log: &log}
+	chain := ChainActivityInboundInterceptors(
+		root,
+		&recordingActivityInboundInterceptorFactory{name: "outer", log: &log},
+		&recordingActivityInboundInterceptorFactory{name: "inner", log: &log},
+	)
+
+	_, err := chain.ExecuteActivity(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"outer", "inner", "root"}, log)
+}
+
+// recordingWorkflowDefinitionFactory/recordingWorkflowDefinition stand in for a real
+// WorkflowDefinitionFactory, recording that Execute actually reached the wrapped definition.
+type recordingWorkflowDefinitionFactory struct {
+	def *recordingWorkflowDefinition
+}
+
+func (f *recordingWorkflowDefinitionFactory) NewWorkflowDefinition() WorkflowDefinition {
+	return f.def
+}
+
+type recordingWorkflowDefinition struct {
+	WorkflowDefinition
+	executed bool
+}
+
+func (d *recordingWorkflowDefinition) Execute(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads) {
+	d.executed = true
+}
+
+type recordingWorkflowInboundInterceptor struct {
+	WorkflowInboundInterceptorBase
+	name string
+	log  *[]string
+}
+
+func (r *recordingWorkflowInboundInterceptor) ExecuteWorkflow(env WorkflowEnvironment, header *commonpb.Header, input *commonpb.Payloads) {
+	*r.log = append(*r.log, r.name)
+	r.next.ExecuteWorkflow(env, header, input)
+}
+
+type recordingWorkflowInboundInterceptorFactory struct {
+	name string
+	log  *[]string
+}
+
+func (f *recordingWorkflowInboundInterceptorFactory) NewInterceptor(next WorkflowInboundInterceptor) WorkflowInboundInterceptor {
+	return &recordingWorkflowInboundInterceptor{
+		WorkflowInboundInterceptorBase: WorkflowInboundInterceptorBase{next: next},
+		name:                           f.name,
+		log:                            f.log,
+	}
+}
+
+func TestInterceptedWorkflowDefinitionFactory_RunsChainThenWrappedExecute(t *testing.T) {
+	var log []string
+	def := &recordingWorkflowDefinition{}
+	factory := NewInterceptedWorkflowDefinitionFactory(
+		&recordingWorkflowDefinitionFactory{def: def},
+		&recordingWorkflowInboundInterceptorFactory{name: "outer", log: &log},
+		&recordingWorkflowInboundInterceptorFactory{name: "inner", log: &log},
+	)
+
+	factory.NewWorkflowDefinition().Execute(nil, nil, nil)
+
+	require.Equal(t, []string{"outer", "inner"}, log)
+	require.True(t, def.executed)
+}
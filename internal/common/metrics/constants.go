@@ -0,0 +1,54 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+// Metric names emitted by internal/internal_worker_base.go (the poller/dispatch pool),
+// internal/deadlock_interceptor.go, and internal/ratelimit_interceptor.go. Each is the
+// scope-local counter/gauge name passed to tally.Scope.Counter/Gauge - callers tag and subscope
+// these themselves, so no prefix is baked in here.
+const (
+	// PollerPanicCounter counts panics recovered in the poller loop itself, as distinct from
+	// WorkerPanicCounter, which counts panics recovered while processing an already-polled task.
+	PollerPanicCounter = "poller-panics"
+
+	// PoolQueueDepthGauge reports how many tasks are waiting in baseWorker's shared dispatch
+	// channel, before runTaskDispatcher assigns them to a shard.
+	PoolQueueDepthGauge = "pool-queue-depth"
+
+	// PoolShardBacklogGauge reports how many tasks are waiting in a single dispatch shard, after
+	// runTaskDispatcher assigns them but before runTaskShard processes them.
+	PoolShardBacklogGauge = "pool-shard-backlog"
+
+	// WorkflowTaskDeadlockCounter counts decision tasks that the interceptor installed by
+	// NewDeadlockDetectorInterceptorFactory (internal/deadlock_interceptor.go) observed running
+	// past the configured threshold without yielding.
+	WorkflowTaskDeadlockCounter = "workflow-task-deadlock"
+
+	// ActivityRateLimitThrottledCounter counts ExecuteActivity/ExecuteLocalActivity calls that the
+	// interceptor installed by NewRateLimitInterceptorFactory delayed waiting for a token-bucket
+	// refill.
+	ActivityRateLimitThrottledCounter = "activity-rate-limit-throttled"
+
+	// ActivityConcurrencyBlockedCounter counts ExecuteActivity/ExecuteLocalActivity calls that the
+	// interceptor installed by NewRateLimitInterceptorFactory delayed waiting for a free
+	// concurrency slot.
+	ActivityConcurrencyBlockedCounter = "activity-concurrency-blocked"
+)
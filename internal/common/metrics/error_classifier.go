@@ -0,0 +1,112 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"go.uber.org/yarpc/yarpcerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorCategory buckets an error returned by the WorkflowService client into one of a small set
+// of outcomes an alerting rule or retry policy cares about.
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown covers a nil error and status codes not mapped to any other category
+	// (e.g. PermissionDenied or Aborted). An error carrying no gRPC status falls through to
+	// yarpcerrors.FromError, which reports it as CodeUnknown, so it lands in ErrorCategoryInternal.
+	ErrorCategoryUnknown ErrorCategory = iota
+	// ErrorCategoryInvalid is a non-retryable problem with the request itself.
+	ErrorCategoryInvalid
+	// ErrorCategoryResourceExhausted means the caller hit a rate limit or quota.
+	ErrorCategoryResourceExhausted
+	// ErrorCategoryUnavailable means the server (or a hop in front of it) is temporarily down.
+	ErrorCategoryUnavailable
+	// ErrorCategoryDeadlineExceeded means the call timed out before the server responded.
+	ErrorCategoryDeadlineExceeded
+	// ErrorCategoryCanceled means the caller's context was canceled before the call completed.
+	ErrorCategoryCanceled
+	// ErrorCategoryInternal is a non-retryable server-side failure.
+	ErrorCategoryInternal
+)
+
+// ErrorClassifier buckets an error returned by the underlying WorkflowService client into an
+// ErrorCategory so the wrapper can emit a distinct counter per category. Users with their own
+// server-side error mappings (e.g. a custom gRPC interceptor that returns application-specific
+// codes) can inject their own classifier via WithErrorClassifier.
+type ErrorClassifier interface {
+	Classify(err error) ErrorCategory
+}
+
+// defaultErrorClassifier understands both yarpcerrors codes (used by the YARPC/Thrift transport)
+// and google.golang.org/grpc/status codes (used once a call goes over native gRPC), so the same
+// wrapper keeps working correctly through the YARPC-to-gRPC migration.
+type defaultErrorClassifier struct{}
+
+func (defaultErrorClassifier) Classify(err error) ErrorCategory {
+	if err == nil {
+		return ErrorCategoryUnknown
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition:
+			return ErrorCategoryInvalid
+		case codes.ResourceExhausted:
+			return ErrorCategoryResourceExhausted
+		case codes.Unavailable:
+			return ErrorCategoryUnavailable
+		case codes.DeadlineExceeded:
+			return ErrorCategoryDeadlineExceeded
+		case codes.Canceled:
+			return ErrorCategoryCanceled
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+			return ErrorCategoryInternal
+		default:
+			return ErrorCategoryUnknown
+		}
+	}
+
+	switch yarpcerrors.FromError(err).Code() {
+	case yarpcerrors.CodeInvalidArgument, yarpcerrors.CodeNotFound, yarpcerrors.CodeAlreadyExists, yarpcerrors.CodeFailedPrecondition:
+		return ErrorCategoryInvalid
+	case yarpcerrors.CodeResourceExhausted:
+		return ErrorCategoryResourceExhausted
+	case yarpcerrors.CodeUnavailable:
+		return ErrorCategoryUnavailable
+	case yarpcerrors.CodeDeadlineExceeded:
+		return ErrorCategoryDeadlineExceeded
+	case yarpcerrors.CodeCancelled:
+		return ErrorCategoryCanceled
+	case yarpcerrors.CodeInternal, yarpcerrors.CodeUnknown, yarpcerrors.CodeDataLoss, yarpcerrors.CodeUnimplemented:
+		return ErrorCategoryInternal
+	default:
+		return ErrorCategoryUnknown
+	}
+}
+
+// WithErrorClassifier overrides the default ErrorClassifier, letting callers bucket their own
+// server-side error mappings correctly instead of having them all land in CadenceError.
+func WithErrorClassifier(classifier ErrorClassifier) WorkflowServiceWrapperOption {
+	return func(w *workflowServiceMetricsWrapperGRPC) {
+		w.errorClassifier = classifier
+	}
+}
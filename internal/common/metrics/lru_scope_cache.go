@@ -0,0 +1,94 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/uber-go/tally"
+)
+
+// namespaceScopeKey identifies a namespace-tagged sub-scope of a single RPC's scope.
+type namespaceScopeKey struct {
+	rpcScopeName string
+	namespace    string
+}
+
+// lruScopeCache bounds the number of namespace-tagged tally.Scope instances
+// workflowServiceMetricsWrapperGRPC keeps alive, so a caller sending unbounded distinct namespace
+// strings can't grow the scope map without limit.
+type lruScopeCache struct {
+	capacity int
+	mutex    sync.Mutex
+	list     *list.List
+	items    map[namespaceScopeKey]*list.Element
+}
+
+type lruScopeCacheEntry struct {
+	key   namespaceScopeKey
+	scope tally.Scope
+}
+
+func newLRUScopeCache(capacity int) *lruScopeCache {
+	return &lruScopeCache{
+		capacity: capacity,
+		list:     list.New(),
+		items:    make(map[namespaceScopeKey]*list.Element),
+	}
+}
+
+func (c *lruScopeCache) get(key namespaceScopeKey) (tally.Scope, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.list.MoveToFront(elem)
+	return elem.Value.(*lruScopeCacheEntry).scope, true
+}
+
+func (c *lruScopeCache) getOrCreate(key namespaceScopeKey, create func() tally.Scope) tally.Scope {
+	if scope, ok := c.get(key); ok {
+		return scope
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// Another caller may have inserted key between the lookup above and taking the lock here.
+	if elem, ok := c.items[key]; ok {
+		c.list.MoveToFront(elem)
+		return elem.Value.(*lruScopeCacheEntry).scope
+	}
+
+	scope := create()
+	elem := c.list.PushFront(&lruScopeCacheEntry{key: key, scope: scope})
+	c.items[key] = elem
+	if c.list.Len() > c.capacity {
+		oldest := c.list.Back()
+		if oldest != nil {
+			c.list.Remove(oldest)
+			delete(c.items, oldest.Value.(*lruScopeCacheEntry).key)
+		}
+	}
+	return scope
+}
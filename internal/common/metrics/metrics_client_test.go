@@ -0,0 +1,120 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	commonpb "github.com/temporalio/temporal-proto/common/v1"
+	eventpb "github.com/temporalio/temporal-proto/event"
+	tasklistpb "github.com/temporalio/temporal-proto/tasklist"
+	"github.com/temporalio/temporal-proto/workflowservice"
+)
+
+func TestProtoToThriftDeprecateDomainRequest_MapsFields(t *testing.T) {
+	request := &workflowservice.DeprecateDomainRequest{Namespace: "my-namespace", SecurityToken: "my-token"}
+
+	thriftRequest, err := protoToThriftDeprecateDomainRequest(request)
+
+	require.NoError(t, err)
+	require.Equal(t, "my-namespace", thriftRequest.GetName())
+	require.Equal(t, "my-token", thriftRequest.GetSecurityToken())
+}
+
+func TestProtoToThriftStartWorkflowExecutionRequest_MapsFields(t *testing.T) {
+	request := &workflowservice.StartWorkflowExecutionRequest{
+		Namespace:    "my-namespace",
+		WorkflowId:   "my-workflow-id",
+		WorkflowType: &commonpb.WorkflowType{Name: "my-workflow-type"},
+		TaskQueue:    &tasklistpb.TaskQueue{Name: "my-task-queue"},
+		Identity:     "my-identity",
+		RequestId:    "my-request-id",
+		CronSchedule: "@daily",
+	}
+
+	thriftRequest, err := protoToThriftStartWorkflowExecutionRequest(request)
+
+	require.NoError(t, err)
+	require.Equal(t, "my-namespace", thriftRequest.GetDomain())
+	require.Equal(t, "my-workflow-id", thriftRequest.GetWorkflowId())
+	require.Equal(t, "my-workflow-type", thriftRequest.GetWorkflowType().GetName())
+	require.Equal(t, "my-task-queue", thriftRequest.GetTaskList().GetName())
+	require.Equal(t, "my-identity", thriftRequest.GetIdentity())
+	require.Equal(t, "my-request-id", thriftRequest.GetRequestId())
+	require.Equal(t, "@daily", thriftRequest.GetCronSchedule())
+}
+
+func TestProtoToThriftStartWorkflowExecutionRequest_RejectsInput(t *testing.T) {
+	request := &workflowservice.StartWorkflowExecutionRequest{Input: &commonpb.Payloads{}}
+
+	_, err := protoToThriftStartWorkflowExecutionRequest(request)
+
+	var unsupported *ErrUnsupportedField
+	require.ErrorAs(t, err, &unsupported)
+	require.Equal(t, "Input", unsupported.FieldName)
+}
+
+func TestProtoToThriftReapplyEventsRequest_MapsFields(t *testing.T) {
+	request := &workflowservice.ReapplyEventsRequest{
+		Namespace:         "my-namespace",
+		WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "my-workflow-id", RunId: "my-run-id"},
+	}
+
+	thriftRequest, err := protoToThriftReapplyEventsRequest(request)
+
+	require.NoError(t, err)
+	require.Equal(t, "my-namespace", thriftRequest.GetDomainName())
+	require.Equal(t, "my-workflow-id", thriftRequest.GetWorkflowExecution().GetWorkflowId())
+	require.Equal(t, "my-run-id", thriftRequest.GetWorkflowExecution().GetRunId())
+}
+
+func TestProtoToThriftReapplyEventsRequest_RejectsEvent(t *testing.T) {
+	request := &workflowservice.ReapplyEventsRequest{Event: &eventpb.HistoryEvent{}}
+
+	_, err := protoToThriftReapplyEventsRequest(request)
+
+	var unsupported *ErrUnsupportedField
+	require.ErrorAs(t, err, &unsupported)
+	require.Equal(t, "Event", unsupported.FieldName)
+}
+
+func TestProtoToThriftRespondDecisionTaskCompletedRequest_MapsFields(t *testing.T) {
+	request := &workflowservice.RespondDecisionTaskCompletedRequest{
+		TaskToken:                  []byte("my-task-token"),
+		ExecutionContext:           []byte("my-execution-context"),
+		Identity:                   "my-identity",
+		ReturnNewDecisionTask:      true,
+		ForceCreateNewDecisionTask: true,
+		BinaryChecksum:             "my-checksum",
+	}
+
+	thriftRequest, err := protoToThriftRespondDecisionTaskCompletedRequest(request)
+
+	require.NoError(t, err)
+	require.Equal(t, []byte("my-task-token"), thriftRequest.GetTaskToken())
+	require.Equal(t, []byte("my-execution-context"), thriftRequest.GetExecutionContext())
+	require.Equal(t, "my-identity", thriftRequest.GetIdentity())
+	require.True(t, thriftRequest.GetReturnNewDecisionTask())
+	require.True(t, thriftRequest.GetForceCreateNewDecisionTask())
+	require.Equal(t, "my-checksum", thriftRequest.GetBinaryChecksum())
+}
@@ -22,11 +22,12 @@ package metrics
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
 	"github.com/uber-go/tally"
-	"go.uber.org/yarpc/yarpcerrors"
 
 	"go.uber.org/yarpc"
 
@@ -35,18 +36,37 @@ import (
 
 type (
 	workflowServiceMetricsWrapperGRPC struct {
-		service     workflowservice.WorkflowServiceYARPCClient
-		scope       tally.Scope
-		childScopes map[string]tally.Scope
-		mutex       sync.Mutex
+		service          workflowservice.WorkflowServiceYARPCClient
+		scope            tally.Scope
+		childScopes      map[string]tally.Scope
+		mutex            sync.Mutex
+		namespaceScopes  *lruScopeCache
+		namespaceTagging bool
+		errorClassifier  ErrorClassifier
 	}
 
 	operationScopeGRPC struct {
-		scope     tally.Scope
-		startTime time.Time
+		scope      tally.Scope
+		startTime  time.Time
+		classifier ErrorClassifier
 	}
+
+	// WorkflowServiceWrapperOption configures NewWorkflowServiceWrapperGRPC.
+	WorkflowServiceWrapperOption func(*workflowServiceMetricsWrapperGRPC)
 )
 
+// namespaceScopeCacheSize bounds how many distinct (rpc, namespace) tagged sub-scopes
+// workflowServiceMetricsWrapperGRPC keeps alive at once.
+const namespaceScopeCacheSize = 1000
+
+// WithoutNamespaceTagging disables tagging every metric with the request's namespace, for users
+// who don't want the added cardinality.
+func WithoutNamespaceTagging() WorkflowServiceWrapperOption {
+	return func(w *workflowServiceMetricsWrapperGRPC) {
+		w.namespaceTagging = false
+	}
+}
+
 const (
 	scopeNameDeprecateDomain                  = CadenceMetricsPrefix + "DeprecateDomain"
 	scopeNameDescribeDomain                   = CadenceMetricsPrefix + "DescribeDomain"
@@ -87,11 +107,24 @@ const (
 	scopeNameGetReplicationMessages           = CadenceMetricsPrefix + "GetReplicationMessages"
 	scopeNameGetDomainReplicationMessages     = CadenceMetricsPrefix + "GetDomainReplicationMessages"
 	scopeNameReapplyEvents                    = CadenceMetricsPrefix + "ReapplyEvents"
+	scopeNameExecuteMultiOperation            = CadenceMetricsPrefix + "ExecuteMultiOperation"
+	scopeNameUpdateWorkflowExecution          = CadenceMetricsPrefix + "UpdateWorkflowExecution"
 )
 
 // NewWorkflowServiceWrapper creates a new wrapper to WorkflowService that will emit metrics for each service call.
-func NewWorkflowServiceWrapperGRPC(service workflowservice.WorkflowServiceYARPCClient, scope tally.Scope) workflowservice.WorkflowServiceYARPCClient {
-	return &workflowServiceMetricsWrapperGRPC{service: service, scope: scope, childScopes: make(map[string]tally.Scope)}
+func NewWorkflowServiceWrapperGRPC(service workflowservice.WorkflowServiceYARPCClient, scope tally.Scope, opts ...WorkflowServiceWrapperOption) workflowservice.WorkflowServiceYARPCClient {
+	w := &workflowServiceMetricsWrapperGRPC{
+		service:          service,
+		scope:            scope,
+		childScopes:      make(map[string]tally.Scope),
+		namespaceScopes:  newLRUScopeCache(namespaceScopeCacheSize),
+		namespaceTagging: true,
+		errorClassifier:  defaultErrorClassifier{},
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
 }
 
 func (w *workflowServiceMetricsWrapperGRPC) getScope(scopeName string) tally.Scope {
@@ -107,297 +140,402 @@ func (w *workflowServiceMetricsWrapperGRPC) getScope(scopeName string) tally.Sco
 	return scope
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) getOperationScope(scopeName string) *operationScopeGRPC {
+// getOperationScope returns the scope for scopeName, tagged with namespace if namespace tagging
+// is enabled and namespace is non-empty. Tagged scopes are cached in an LRU keyed by
+// (scopeName, namespace) so a caller sending many distinct namespace strings can't grow the
+// wrapper's scope set without bound.
+func (w *workflowServiceMetricsWrapperGRPC) getOperationScope(scopeName string, namespace string) *operationScopeGRPC {
 	scope := w.getScope(scopeName)
+	if w.namespaceTagging && namespace != "" {
+		key := namespaceScopeKey{rpcScopeName: scopeName, namespace: namespace}
+		scope = w.namespaceScopes.getOrCreate(key, func() tally.Scope {
+			return scope.Tagged(map[string]string{"namespace": namespace})
+		})
+	}
 	scope.Counter(CadenceRequest).Inc(1)
 
-	return &operationScopeGRPC{scope: scope, startTime: time.Now()}
+	return &operationScopeGRPC{scope: scope, startTime: time.Now(), classifier: w.errorClassifier}
 }
 
 func (s *operationScopeGRPC) handleError(err error) {
 	s.scope.Timer(CadenceLatency).Record(time.Now().Sub(s.startTime))
-	if err != nil {
-		st := yarpcerrors.FromError(err)
-		switch st.Code() {
-		case yarpcerrors.CodeNotFound,
-			yarpcerrors.CodeInvalidArgument,
-			yarpcerrors.CodeAlreadyExists:
-			s.scope.Counter(CadenceInvalidRequest).Inc(1)
-		default:
-			s.scope.Counter(CadenceError).Inc(1)
-		}
+	if err == nil {
+		return
+	}
+	s.countError(s.scope, err)
+}
+
+// countError classifies err with this operation scope's ErrorClassifier and increments the
+// matching counter on scope. scope need not be s.scope: ExecuteMultiOperation uses this to
+// attribute a sub-operation's error to that sub-operation's own child scope while still using the
+// aggregate scope's classifier.
+func (s *operationScopeGRPC) countError(scope tally.Scope, err error) {
+	switch s.classifier.Classify(err) {
+	case ErrorCategoryInvalid:
+		scope.Counter(CadenceInvalidRequest).Inc(1)
+	case ErrorCategoryResourceExhausted:
+		scope.Counter(CadenceResourceExhausted).Inc(1)
+	case ErrorCategoryUnavailable:
+		scope.Counter(CadenceUnavailable).Inc(1)
+	case ErrorCategoryDeadlineExceeded:
+		scope.Counter(CadenceDeadlineExceeded).Inc(1)
+	default:
+		scope.Counter(CadenceError).Inc(1)
 	}
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) DeprecateDomain(ctx context.Context, request *workflowservice.DeprecateDomainRequest, opts ...yarpc.CallOption) (*workflowservice.DeprecateDomainResponse, error) {
-	scope := w.getOperationScope(scopeNameDeprecateDomain)
-	result, err := w.service.DeprecateDomain(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+// finish finalizes an operation scope. If recovered is non-nil (a panic was caught by the
+// caller's deferred recover()), it bumps CadencePanic, captures the stack, and rewrites *err so
+// the panic surfaces to the caller as a plain error instead of unwinding through it. Every RPC
+// method except ExecuteMultiOperation (which does the same steps inline, to also attribute
+// sub-operation errors) calls this as defer scope.finish(&err, recover()) so a panic anywhere in
+// the transport below this wrapper - or in a chained interceptor - can't crash the caller
+// unnoticed.
+func (s *operationScopeGRPC) finish(err *error, recovered interface{}) {
+	if recovered != nil {
+		s.scope.Counter(CadencePanic).Inc(1)
+		*err = fmt.Errorf("panic in WorkflowService call: %v\n%s", recovered, debug.Stack())
+	}
+	s.handleError(*err)
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ListDomains(ctx context.Context, request *workflowservice.ListDomainsRequest, opts ...yarpc.CallOption) (*workflowservice.ListDomainsResponse, error) {
-	scope := w.getOperationScope(scopeNameListDomains)
-	result, err := w.service.ListDomains(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) DeprecateDomain(ctx context.Context, request *workflowservice.DeprecateDomainRequest, opts ...yarpc.CallOption) (result *workflowservice.DeprecateDomainResponse, err error) {
+	scope := w.getOperationScope(scopeNameDeprecateDomain, request.Name)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.DeprecateDomain(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) DescribeDomain(ctx context.Context, request *workflowservice.DescribeDomainRequest, opts ...yarpc.CallOption) (*workflowservice.DescribeDomainResponse, error) {
-	scope := w.getOperationScope(scopeNameDescribeDomain)
-	result, err := w.service.DescribeDomain(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ListDomains(ctx context.Context, request *workflowservice.ListDomainsRequest, opts ...yarpc.CallOption) (result *workflowservice.ListDomainsResponse, err error) {
+	scope := w.getOperationScope(scopeNameListDomains, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ListDomains(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) DescribeWorkflowExecution(ctx context.Context, request *workflowservice.DescribeWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.DescribeWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameDescribeWorkflowExecution)
-	result, err := w.service.DescribeWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) DescribeDomain(ctx context.Context, request *workflowservice.DescribeDomainRequest, opts ...yarpc.CallOption) (result *workflowservice.DescribeDomainResponse, err error) {
+	scope := w.getOperationScope(scopeNameDescribeDomain, request.Name)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.DescribeDomain(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest, opts ...yarpc.CallOption) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
-	scope := w.getOperationScope(scopeNameGetWorkflowExecutionHistory)
-	result, err := w.service.GetWorkflowExecutionHistory(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) DescribeWorkflowExecution(ctx context.Context, request *workflowservice.DescribeWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.DescribeWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameDescribeWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.DescribeWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ListClosedWorkflowExecutions(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.ListClosedWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameListClosedWorkflowExecutions)
-	result, err := w.service.ListClosedWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) GetWorkflowExecutionHistory(ctx context.Context, request *workflowservice.GetWorkflowExecutionHistoryRequest, opts ...yarpc.CallOption) (result *workflowservice.GetWorkflowExecutionHistoryResponse, err error) {
+	scope := w.getOperationScope(scopeNameGetWorkflowExecutionHistory, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.GetWorkflowExecutionHistory(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ListOpenWorkflowExecutions(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.ListOpenWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameListOpenWorkflowExecutions)
-	result, err := w.service.ListOpenWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ListClosedWorkflowExecutions(ctx context.Context, request *workflowservice.ListClosedWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.ListClosedWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameListClosedWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ListClosedWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ListWorkflowExecutions(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.ListWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameListWorkflowExecutions)
-	result, err := w.service.ListWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ListOpenWorkflowExecutions(ctx context.Context, request *workflowservice.ListOpenWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.ListOpenWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameListOpenWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ListOpenWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ListArchivedWorkflowExecutions(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.ListArchivedWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameListArchivedWorkflowExecutions)
-	result, err := w.service.ListArchivedWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ListWorkflowExecutions(ctx context.Context, request *workflowservice.ListWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.ListWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameListWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ListWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ScanWorkflowExecutions(ctx context.Context, request *workflowservice.ScanWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.ScanWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameScanWorkflowExecutions)
-	result, err := w.service.ScanWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ListArchivedWorkflowExecutions(ctx context.Context, request *workflowservice.ListArchivedWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.ListArchivedWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameListArchivedWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ListArchivedWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) CountWorkflowExecutions(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest, opts ...yarpc.CallOption) (*workflowservice.CountWorkflowExecutionsResponse, error) {
-	scope := w.getOperationScope(scopeNameCountWorkflowExecutions)
-	result, err := w.service.CountWorkflowExecutions(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ScanWorkflowExecutions(ctx context.Context, request *workflowservice.ScanWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.ScanWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameScanWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ScanWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) PollForActivityTask(ctx context.Context, request *workflowservice.PollForActivityTaskRequest, opts ...yarpc.CallOption) (*workflowservice.PollForActivityTaskResponse, error) {
-	scope := w.getOperationScope(scopeNamePollForActivityTask)
-	result, err := w.service.PollForActivityTask(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) CountWorkflowExecutions(ctx context.Context, request *workflowservice.CountWorkflowExecutionsRequest, opts ...yarpc.CallOption) (result *workflowservice.CountWorkflowExecutionsResponse, err error) {
+	scope := w.getOperationScope(scopeNameCountWorkflowExecutions, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.CountWorkflowExecutions(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) PollForDecisionTask(ctx context.Context, request *workflowservice.PollForDecisionTaskRequest, opts ...yarpc.CallOption) (*workflowservice.PollForDecisionTaskResponse, error) {
-	scope := w.getOperationScope(scopeNamePollForDecisionTask)
-	result, err := w.service.PollForDecisionTask(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) PollForActivityTask(ctx context.Context, request *workflowservice.PollForActivityTaskRequest, opts ...yarpc.CallOption) (result *workflowservice.PollForActivityTaskResponse, err error) {
+	scope := w.getOperationScope(scopeNamePollForActivityTask, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.PollForActivityTask(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RecordActivityTaskHeartbeat(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatRequest, opts ...yarpc.CallOption) (*workflowservice.RecordActivityTaskHeartbeatResponse, error) {
-	scope := w.getOperationScope(scopeNameRecordActivityTaskHeartbeat)
-	result, err := w.service.RecordActivityTaskHeartbeat(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) PollForDecisionTask(ctx context.Context, request *workflowservice.PollForDecisionTaskRequest, opts ...yarpc.CallOption) (result *workflowservice.PollForDecisionTaskResponse, err error) {
+	scope := w.getOperationScope(scopeNamePollForDecisionTask, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.PollForDecisionTask(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RecordActivityTaskHeartbeatByID(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatByIDRequest, opts ...yarpc.CallOption) (*workflowservice.RecordActivityTaskHeartbeatByIDResponse, error) {
-	scope := w.getOperationScope(scopeNameRecordActivityTaskHeartbeatByID)
-	result, err := w.service.RecordActivityTaskHeartbeatByID(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RecordActivityTaskHeartbeat(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatRequest, opts ...yarpc.CallOption) (result *workflowservice.RecordActivityTaskHeartbeatResponse, err error) {
+	scope := w.getOperationScope(scopeNameRecordActivityTaskHeartbeat, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RecordActivityTaskHeartbeat(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RegisterDomain(ctx context.Context, request *workflowservice.RegisterDomainRequest, opts ...yarpc.CallOption) (*workflowservice.RegisterDomainResponse, error) {
-	scope := w.getOperationScope(scopeNameRegisterDomain)
-	result, err := w.service.RegisterDomain(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RecordActivityTaskHeartbeatByID(ctx context.Context, request *workflowservice.RecordActivityTaskHeartbeatByIDRequest, opts ...yarpc.CallOption) (result *workflowservice.RecordActivityTaskHeartbeatByIDResponse, err error) {
+	scope := w.getOperationScope(scopeNameRecordActivityTaskHeartbeatByID, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RecordActivityTaskHeartbeatByID(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RequestCancelWorkflowExecution(ctx context.Context, request *workflowservice.RequestCancelWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.RequestCancelWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameRequestCancelWorkflowExecution)
-	result, err := w.service.RequestCancelWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RegisterDomain(ctx context.Context, request *workflowservice.RegisterDomainRequest, opts ...yarpc.CallOption) (result *workflowservice.RegisterDomainResponse, err error) {
+	scope := w.getOperationScope(scopeNameRegisterDomain, request.Name)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RegisterDomain(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCanceled(ctx context.Context, request *workflowservice.RespondActivityTaskCanceledRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskCanceledResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskCanceled)
-	result, err := w.service.RespondActivityTaskCanceled(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RequestCancelWorkflowExecution(ctx context.Context, request *workflowservice.RequestCancelWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.RequestCancelWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameRequestCancelWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RequestCancelWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCompleted(ctx context.Context, request *workflowservice.RespondActivityTaskCompletedRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskCompletedResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskCompleted)
-	result, err := w.service.RespondActivityTaskCompleted(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCanceled(ctx context.Context, request *workflowservice.RespondActivityTaskCanceledRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskCanceledResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskCanceled, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskCanceled(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskFailed(ctx context.Context, request *workflowservice.RespondActivityTaskFailedRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskFailedResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskFailed)
-	result, err := w.service.RespondActivityTaskFailed(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCompleted(ctx context.Context, request *workflowservice.RespondActivityTaskCompletedRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskCompletedResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskCompleted, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskCompleted(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCanceledByID(ctx context.Context, request *workflowservice.RespondActivityTaskCanceledByIDRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskCanceledByIDResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskCanceledByID)
-	result, err := w.service.RespondActivityTaskCanceledByID(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskFailed(ctx context.Context, request *workflowservice.RespondActivityTaskFailedRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskFailedResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskFailed, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskFailed(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCompletedByID(ctx context.Context, request *workflowservice.RespondActivityTaskCompletedByIDRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskCompletedByIDResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskCompletedByID)
-	result, err := w.service.RespondActivityTaskCompletedByID(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCanceledByID(ctx context.Context, request *workflowservice.RespondActivityTaskCanceledByIDRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskCanceledByIDResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskCanceledByID, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskCanceledByID(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskFailedByID(ctx context.Context, request *workflowservice.RespondActivityTaskFailedByIDRequest, opts ...yarpc.CallOption) (*workflowservice.RespondActivityTaskFailedByIDResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondActivityTaskFailedByID)
-	result, err := w.service.RespondActivityTaskFailedByID(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskCompletedByID(ctx context.Context, request *workflowservice.RespondActivityTaskCompletedByIDRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskCompletedByIDResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskCompletedByID, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskCompletedByID(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondDecisionTaskCompleted(ctx context.Context, request *workflowservice.RespondDecisionTaskCompletedRequest, opts ...yarpc.CallOption) (*workflowservice.RespondDecisionTaskCompletedResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondDecisionTaskCompleted)
-	result, err := w.service.RespondDecisionTaskCompleted(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondActivityTaskFailedByID(ctx context.Context, request *workflowservice.RespondActivityTaskFailedByIDRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondActivityTaskFailedByIDResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondActivityTaskFailedByID, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondActivityTaskFailedByID(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondDecisionTaskFailed(ctx context.Context, request *workflowservice.RespondDecisionTaskFailedRequest, opts ...yarpc.CallOption) (*workflowservice.RespondDecisionTaskFailedResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondDecisionTaskFailed)
-	result, err := w.service.RespondDecisionTaskFailed(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondDecisionTaskCompleted(ctx context.Context, request *workflowservice.RespondDecisionTaskCompletedRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondDecisionTaskCompletedResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondDecisionTaskCompleted, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondDecisionTaskCompleted(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) SignalWorkflowExecution(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.SignalWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameSignalWorkflowExecution)
-	result, err := w.service.SignalWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondDecisionTaskFailed(ctx context.Context, request *workflowservice.RespondDecisionTaskFailedRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondDecisionTaskFailedResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondDecisionTaskFailed, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondDecisionTaskFailed(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) SignalWithStartWorkflowExecution(ctx context.Context, request *workflowservice.SignalWithStartWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.SignalWithStartWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameSignalWithStartWorkflowExecution)
-	result, err := w.service.SignalWithStartWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) SignalWorkflowExecution(ctx context.Context, request *workflowservice.SignalWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.SignalWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameSignalWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.SignalWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) StartWorkflowExecution(ctx context.Context, request *workflowservice.StartWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.StartWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameStartWorkflowExecution)
-	result, err := w.service.StartWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) SignalWithStartWorkflowExecution(ctx context.Context, request *workflowservice.SignalWithStartWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.SignalWithStartWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameSignalWithStartWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.SignalWithStartWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) TerminateWorkflowExecution(ctx context.Context, request *workflowservice.TerminateWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.TerminateWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameTerminateWorkflowExecution)
-	result, err := w.service.TerminateWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) StartWorkflowExecution(ctx context.Context, request *workflowservice.StartWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.StartWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameStartWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.StartWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (*workflowservice.ResetWorkflowExecutionResponse, error) {
-	scope := w.getOperationScope(scopeNameResetWorkflowExecution)
-	result, err := w.service.ResetWorkflowExecution(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) TerminateWorkflowExecution(ctx context.Context, request *workflowservice.TerminateWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.TerminateWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameTerminateWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.TerminateWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) UpdateDomain(ctx context.Context, request *workflowservice.UpdateDomainRequest, opts ...yarpc.CallOption) (*workflowservice.UpdateDomainResponse, error) {
-	scope := w.getOperationScope(scopeNameUpdateDomain)
-	result, err := w.service.UpdateDomain(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ResetWorkflowExecution(ctx context.Context, request *workflowservice.ResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (result *workflowservice.ResetWorkflowExecutionResponse, err error) {
+	scope := w.getOperationScope(scopeNameResetWorkflowExecution, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ResetWorkflowExecution(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) QueryWorkflow(ctx context.Context, request *workflowservice.QueryWorkflowRequest, opts ...yarpc.CallOption) (*workflowservice.QueryWorkflowResponse, error) {
-	scope := w.getOperationScope(scopeNameQueryWorkflow)
-	result, err := w.service.QueryWorkflow(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) UpdateDomain(ctx context.Context, request *workflowservice.UpdateDomainRequest, opts ...yarpc.CallOption) (result *workflowservice.UpdateDomainResponse, err error) {
+	scope := w.getOperationScope(scopeNameUpdateDomain, request.Name)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.UpdateDomain(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ResetStickyTaskList(ctx context.Context, request *workflowservice.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (*workflowservice.ResetStickyTaskListResponse, error) {
-	scope := w.getOperationScope(scopeNameResetStickyTaskList)
-	result, err := w.service.ResetStickyTaskList(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) QueryWorkflow(ctx context.Context, request *workflowservice.QueryWorkflowRequest, opts ...yarpc.CallOption) (result *workflowservice.QueryWorkflowResponse, err error) {
+	scope := w.getOperationScope(scopeNameQueryWorkflow, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.QueryWorkflow(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) DescribeTaskList(ctx context.Context, request *workflowservice.DescribeTaskListRequest, opts ...yarpc.CallOption) (*workflowservice.DescribeTaskListResponse, error) {
-	scope := w.getOperationScope(scopeNameDescribeTaskList)
-	result, err := w.service.DescribeTaskList(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) ResetStickyTaskList(ctx context.Context, request *workflowservice.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (result *workflowservice.ResetStickyTaskListResponse, err error) {
+	scope := w.getOperationScope(scopeNameResetStickyTaskList, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ResetStickyTaskList(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) RespondQueryTaskCompleted(ctx context.Context, request *workflowservice.RespondQueryTaskCompletedRequest, opts ...yarpc.CallOption) (*workflowservice.RespondQueryTaskCompletedResponse, error) {
-	scope := w.getOperationScope(scopeNameRespondQueryTaskCompleted)
-	result, err := w.service.RespondQueryTaskCompleted(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) DescribeTaskList(ctx context.Context, request *workflowservice.DescribeTaskListRequest, opts ...yarpc.CallOption) (result *workflowservice.DescribeTaskListResponse, err error) {
+	scope := w.getOperationScope(scopeNameDescribeTaskList, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.DescribeTaskList(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) GetSearchAttributes(ctx context.Context, request *workflowservice.GetSearchAttributesRequest, opts ...yarpc.CallOption) (*workflowservice.GetSearchAttributesResponse, error) {
-	scope := w.getOperationScope(scopeNameGetSearchAttributes)
-	result, err := w.service.GetSearchAttributes(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) RespondQueryTaskCompleted(ctx context.Context, request *workflowservice.RespondQueryTaskCompletedRequest, opts ...yarpc.CallOption) (result *workflowservice.RespondQueryTaskCompletedResponse, err error) {
+	scope := w.getOperationScope(scopeNameRespondQueryTaskCompleted, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.RespondQueryTaskCompleted(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) GetReplicationMessages(ctx context.Context, request *workflowservice.GetReplicationMessagesRequest, opts ...yarpc.CallOption) (*workflowservice.GetReplicationMessagesResponse, error) {
-	scope := w.getOperationScope(scopeNameGetReplicationMessages)
-	result, err := w.service.GetReplicationMessages(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) GetSearchAttributes(ctx context.Context, request *workflowservice.GetSearchAttributesRequest, opts ...yarpc.CallOption) (result *workflowservice.GetSearchAttributesResponse, err error) {
+	scope := w.getOperationScope(scopeNameGetSearchAttributes, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.GetSearchAttributes(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) GetDomainReplicationMessages(ctx context.Context, request *workflowservice.GetDomainReplicationMessagesRequest, opts ...yarpc.CallOption) (*workflowservice.GetDomainReplicationMessagesResponse, error) {
-	scope := w.getOperationScope(scopeNameGetDomainReplicationMessages)
-	result, err := w.service.GetDomainReplicationMessages(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
+func (w *workflowServiceMetricsWrapperGRPC) GetReplicationMessages(ctx context.Context, request *workflowservice.GetReplicationMessagesRequest, opts ...yarpc.CallOption) (result *workflowservice.GetReplicationMessagesResponse, err error) {
+	scope := w.getOperationScope(scopeNameGetReplicationMessages, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.GetReplicationMessages(ctx, request, opts...)
+	return
 }
 
-func (w *workflowServiceMetricsWrapperGRPC) ReapplyEvents(ctx context.Context, request *workflowservice.ReapplyEventsRequest, opts ...yarpc.CallOption) (*workflowservice.ReapplyEventsResponse, error) {
-	scope := w.getOperationScope(scopeNameReapplyEvents)
-	result, err := w.service.ReapplyEvents(ctx, request, opts...)
-	scope.handleError(err)
-	return result, err
-}
\ No newline at end of file
+func (w *workflowServiceMetricsWrapperGRPC) GetDomainReplicationMessages(ctx context.Context, request *workflowservice.GetDomainReplicationMessagesRequest, opts ...yarpc.CallOption) (result *workflowservice.GetDomainReplicationMessagesResponse, err error) {
+	scope := w.getOperationScope(scopeNameGetDomainReplicationMessages, "")
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.GetDomainReplicationMessages(ctx, request, opts...)
+	return
+}
+
+func (w *workflowServiceMetricsWrapperGRPC) ReapplyEvents(ctx context.Context, request *workflowservice.ReapplyEventsRequest, opts ...yarpc.CallOption) (result *workflowservice.ReapplyEventsResponse, err error) {
+	scope := w.getOperationScope(scopeNameReapplyEvents, request.Domain)
+	defer func() { scope.finish(&err, recover()) }()
+	result, err = w.service.ReapplyEvents(ctx, request, opts...)
+	return
+}
+
+// multiOperationError is satisfied by the error the server returns when one or more of an
+// ExecuteMultiOperation request's sub-operations failed. Each entry lines up by index with
+// request.Operations, with a nil entry for a sub-operation that itself succeeded.
+type multiOperationError interface {
+	error
+	OperationErrors() []error
+}
+
+// multiOperationChildScope returns the child operation scope (StartWorkflowExecution or
+// UpdateWorkflowExecution) that op's sub-operation rolls up to, bumping that scope's
+// CadenceRequest counter as a side effect so per-operation rates stay correct regardless of
+// whether the caller used the batched RPC or called the sub-operation directly. Returns nil for
+// an operation of a kind this wrapper doesn't know how to attribute.
+func (w *workflowServiceMetricsWrapperGRPC) multiOperationChildScope(op *workflowservice.ExecuteMultiOperationRequest_Operation, namespace string) *operationScopeGRPC {
+	switch {
+	case op.GetStartWorkflow() != nil:
+		return w.getOperationScope(scopeNameStartWorkflowExecution, namespace)
+	case op.GetUpdateWorkflow() != nil:
+		return w.getOperationScope(scopeNameUpdateWorkflowExecution, namespace)
+	default:
+		return nil
+	}
+}
+
+// ExecuteMultiOperation bundles a StartWorkflowExecution with an UpdateWorkflowExecution
+// (eager-start / update-with-start). In addition to the overall latency and error recorded on the
+// aggregate ExecuteMultiOperation scope, every sub-operation's CadenceRequest is attributed to its
+// own child scope, and a per-operation failure reported via MultiOperationExecution is attributed
+// to that sub-operation's child scope instead of only counting once against the aggregate - so
+// StartWorkflowExecution/UpdateWorkflowExecution dashboards reflect combined rates whether or not
+// the caller used the batched RPC.
+func (w *workflowServiceMetricsWrapperGRPC) ExecuteMultiOperation(ctx context.Context, request *workflowservice.ExecuteMultiOperationRequest, opts ...yarpc.CallOption) (result *workflowservice.ExecuteMultiOperationResponse, err error) {
+	scope := w.getOperationScope(scopeNameExecuteMultiOperation, request.Namespace)
+	childScopes := make([]*operationScopeGRPC, len(request.Operations))
+	for i, op := range request.Operations {
+		childScopes[i] = w.multiOperationChildScope(op, request.Namespace)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			scope.scope.Counter(CadencePanic).Inc(1)
+			err = fmt.Errorf("panic in WorkflowService call: %v\n%s", r, debug.Stack())
+		}
+		scope.scope.Timer(CadenceLatency).Record(time.Now().Sub(scope.startTime))
+		if err == nil {
+			return
+		}
+		// The aggregate scope always counts the error, same as every other RPC method - in
+		// addition, a per-operation failure reported via MultiOperationExecution is also
+		// attributed to that sub-operation's own child scope, so StartWorkflowExecution /
+		// UpdateWorkflowExecution dashboards reflect combined rates whether or not the caller
+		// used the batched RPC.
+		if moErr, ok := err.(multiOperationError); ok {
+			opErrors := moErr.OperationErrors()
+			for i, childScope := range childScopes {
+				if childScope == nil || i >= len(opErrors) || opErrors[i] == nil {
+					continue
+				}
+				scope.countError(childScope.scope, opErrors[i])
+			}
+		}
+		scope.countError(scope.scope, err)
+	}()
+
+	result, err = w.service.ExecuteMultiOperation(ctx, request, opts...)
+	return
+}
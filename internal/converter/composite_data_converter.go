@@ -0,0 +1,124 @@
+package converter
+
+import (
+	"fmt"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+// CompositeDataConverter is a DataConverter backed by an ordered chain of PayloadConverter
+// plugins. On ToData, each value is offered to the converters in priority order until one
+// accepts it; the accepting converter's encoding is stamped into the resulting PayloadItem's
+// metadata. On FromData, that encoding tag is read back and used to pick the matching
+// converter, so users can add a new encoding by registering a converter instead of forking the
+// whole DataConverter.
+type CompositeDataConverter struct {
+	converters     []PayloadConverter
+	converterByEnc map[string]PayloadConverter
+}
+
+var _ DataConverter = (*CompositeDataConverter)(nil)
+
+// NewCompositeDataConverter creates a CompositeDataConverter from the given PayloadConverters,
+// tried in the order they are passed in.
+func NewCompositeDataConverter(converters ...PayloadConverter) *CompositeDataConverter {
+	dc := &CompositeDataConverter{
+		converterByEnc: make(map[string]PayloadConverter, len(converters)),
+	}
+	for _, pc := range converters {
+		dc.RegisterPayloadConverter(pc.Encoding(), pc)
+	}
+	return dc
+}
+
+// RegisterPayloadConverter adds pc to the conversion chain under encoding. A first-time
+// registration is appended to the end of the chain, so building up the initial chain (as
+// NewCompositeDataConverter does) preserves the order its converters were passed in. Registering
+// an encoding that already has a converter removes the old one from its current slot and moves pc
+// to the front of the chain, giving the override priority over every converter that was already
+// there - so users can swap in a custom converter (e.g. a different JSON encoding) without
+// rebuilding the whole chain.
+func (dc *CompositeDataConverter) RegisterPayloadConverter(encoding string, pc PayloadConverter) {
+	if _, exists := dc.converterByEnc[encoding]; exists {
+		for i, existing := range dc.converters {
+			if existing.Encoding() == encoding {
+				dc.converters = append(dc.converters[:i], dc.converters[i+1:]...)
+				break
+			}
+		}
+		dc.converters = append([]PayloadConverter{pc}, dc.converters...)
+	} else {
+		dc.converters = append(dc.converters, pc)
+	}
+	dc.converterByEnc[encoding] = pc
+}
+
+// ToData implements DataConverter.ToData.
+func (dc *CompositeDataConverter) ToData(values ...interface{}) (*commonpb.Payload, error) {
+	payload := &commonpb.Payload{}
+	for i, value := range values {
+		item, err := dc.toPayloadItem(value)
+		if err != nil {
+			return nil, fmt.Errorf("values[%d]: %w", i, err)
+		}
+		payload.Items = append(payload.Items, item)
+	}
+	return payload, nil
+}
+
+func (dc *CompositeDataConverter) toPayloadItem(value interface{}) (*commonpb.PayloadItem, error) {
+	for _, pc := range dc.converters {
+		item, ok, err := pc.ToData(value)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return item, nil
+		}
+	}
+	return nil, ErrUnableToFindConverter
+}
+
+// FromData implements DataConverter.FromData.
+func (dc *CompositeDataConverter) FromData(payload *commonpb.Payload, valuePtrs ...interface{}) error {
+	items := payload.GetItems()
+	for i := range items {
+		if i >= len(valuePtrs) {
+			break
+		}
+		if err := dc.fromPayloadItem(items[i], valuePtrs[i]); err != nil {
+			return fmt.Errorf("args[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (dc *CompositeDataConverter) fromPayloadItem(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	encoding, ok := item.GetMetadata()[MetadataEncoding]
+	if !ok {
+		return ErrEncodingIsNotSet
+	}
+	pc, ok := dc.converterByEnc[string(encoding)]
+	if !ok {
+		return fmt.Errorf("encoding %q: %w", encoding, ErrEncodingIsNotSupported)
+	}
+	return pc.FromData(item, valuePtr)
+}
+
+// NewDefaultDataConverter creates a CompositeDataConverter with the SDK's default set of
+// PayloadConverters, in priority order: nil, protobuf JSON, protobuf binary, then JSON as the
+// catch-all for everything else.
+func NewDefaultDataConverter() *CompositeDataConverter {
+	return NewCompositeDataConverter(
+		NewNilPayloadConverter(),
+		NewProtoJSONPayloadConverter(),
+		NewProtoPayloadConverter(),
+		NewJSONPayloadConverter(),
+	)
+}
+
+// Default is the package-level CompositeDataConverter built by NewDefaultDataConverter. It is the
+// instance internal.WorkflowEnvironment.GetDataConverter should be backed by; this tree's
+// WorkflowEnvironment implementation (internal_event_handlers.go in the full SDK) is not part of
+// this snapshot, so that wiring can't be made here - Default is the piece it would call into.
+var Default DataConverter = NewDefaultDataConverter()
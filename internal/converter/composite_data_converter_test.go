@@ -0,0 +1,29 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+func TestNewDefaultDataConverter_PrefersProtoJSONOverPlainJSON(t *testing.T) {
+	dc := NewDefaultDataConverter()
+
+	in := &commonpb.PayloadItem{
+		Metadata: map[string][]byte{"encoding": []byte("binary/protobuf")},
+		Data:     []byte("payload"),
+	}
+	payload, err := dc.ToData(in)
+	require.NoError(t, err)
+	require.Len(t, payload.GetItems(), 1)
+	// JSONPayloadConverter would also happily accept a proto.Message, so a round-trip that
+	// lands on "json/protobuf" instead of "json/plain" proves ProtoJSONPayloadConverter is tried
+	// ahead of JSONPayloadConverter (and of ProtoPayloadConverter) in the chain
+	// NewDefaultDataConverter builds.
+	require.Equal(t, encodingProtoJSON, string(payload.GetItems()[0].GetMetadata()[MetadataEncoding]))
+
+	var out commonpb.PayloadItem
+	require.NoError(t, dc.FromData(payload, &out))
+	require.Equal(t, in.GetData(), out.GetData())
+}
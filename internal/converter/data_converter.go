@@ -0,0 +1,14 @@
+package converter
+
+import (
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+// DataConverter is used by the framework to serialize/deserialize arguments and return values
+// that need to be passed over the wire between client, server and worker.
+type DataConverter interface {
+	// ToData serializes a list of values into a single Payload, one PayloadItem per value.
+	ToData(value ...interface{}) (*commonpb.Payload, error)
+	// FromData deserializes a Payload produced by ToData into valuePtrs, in order.
+	FromData(payload *commonpb.Payload, valuePtrs ...interface{}) error
+}
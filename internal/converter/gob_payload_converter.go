@@ -0,0 +1,49 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const encodingGob = "binary/gob"
+
+// GobPayloadConverter converts Go values to/from gob. Unlike encoding/json, gob round-trips
+// unexported struct fields and preserves concrete types behind interfaces, at the cost of
+// requiring both sides of the wire to share the same Go types.
+type GobPayloadConverter struct{}
+
+// NewGobPayloadConverter creates a new GobPayloadConverter.
+func NewGobPayloadConverter() *GobPayloadConverter {
+	return &GobPayloadConverter{}
+}
+
+// ToData implements PayloadConverter.ToData.
+func (c *GobPayloadConverter) ToData(value interface{}) (*commonpb.PayloadItem, bool, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, true, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+	}
+	return &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding: []byte(c.Encoding()),
+		},
+		Data: buf.Bytes(),
+	}, true, nil
+}
+
+// FromData implements PayloadConverter.FromData.
+func (c *GobPayloadConverter) FromData(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	dec := gob.NewDecoder(bytes.NewBuffer(item.GetData()))
+	if err := dec.Decode(valuePtr); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// Encoding implements PayloadConverter.Encoding.
+func (c *GobPayloadConverter) Encoding() string {
+	return encodingGob
+}
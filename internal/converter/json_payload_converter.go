@@ -0,0 +1,46 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const encodingJSON = "json/plain"
+
+// JSONPayloadConverter converts Go values to/from JSON. It is typically registered last in a
+// CompositeDataConverter since it accepts any value that encoding/json can marshal.
+type JSONPayloadConverter struct{}
+
+// NewJSONPayloadConverter creates a new JSONPayloadConverter.
+func NewJSONPayloadConverter() *JSONPayloadConverter {
+	return &JSONPayloadConverter{}
+}
+
+// ToData implements PayloadConverter.ToData.
+func (c *JSONPayloadConverter) ToData(value interface{}) (*commonpb.PayloadItem, bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+	}
+	return &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding: []byte(c.Encoding()),
+		},
+		Data: data,
+	}, true, nil
+}
+
+// FromData implements PayloadConverter.FromData.
+func (c *JSONPayloadConverter) FromData(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	if err := json.Unmarshal(item.GetData(), valuePtr); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// Encoding implements PayloadConverter.Encoding.
+func (c *JSONPayloadConverter) Encoding() string {
+	return encodingJSON
+}
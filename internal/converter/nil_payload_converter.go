@@ -0,0 +1,39 @@
+package converter
+
+import (
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const encodingNil = "binary/null"
+
+// NilPayloadConverter converts nil to/from a PayloadItem. It only accepts untyped nil values;
+// a nil pointer or interface of a concrete type is left for a later converter to handle.
+type NilPayloadConverter struct{}
+
+// NewNilPayloadConverter creates a new NilPayloadConverter.
+func NewNilPayloadConverter() *NilPayloadConverter {
+	return &NilPayloadConverter{}
+}
+
+// ToData implements PayloadConverter.ToData.
+func (c *NilPayloadConverter) ToData(value interface{}) (*commonpb.PayloadItem, bool, error) {
+	if value != nil {
+		return nil, false, nil
+	}
+	return &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding: []byte(c.Encoding()),
+		},
+	}, true, nil
+}
+
+// FromData implements PayloadConverter.FromData.
+func (c *NilPayloadConverter) FromData(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	// Nothing to decode, the presence of the item already conveys nil.
+	return nil
+}
+
+// Encoding implements PayloadConverter.Encoding.
+func (c *NilPayloadConverter) Encoding() string {
+	return encodingNil
+}
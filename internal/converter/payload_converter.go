@@ -0,0 +1,26 @@
+package converter
+
+import (
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const (
+	// MetadataEncoding is the Metadata key for the encoding tag a PayloadConverter stamps onto
+	// every PayloadItem it produces, e.g. "json/plain" or "binary/protobuf".
+	MetadataEncoding = "encoding"
+)
+
+// PayloadConverter converts a single Go value to and from a PayloadItem tagged with the
+// encoding it was written with. A DataConverter composes PayloadConverters to support more
+// than one encoding without replacing the whole conversion pipeline.
+type PayloadConverter interface {
+	// ToData converts a single value to a PayloadItem. ok is false if this converter does not
+	// know how to handle values of this type, in which case err must be nil so the caller can
+	// offer the value to the next converter in the chain.
+	ToData(value interface{}) (item *commonpb.PayloadItem, ok bool, err error)
+	// FromData populates valuePtr from a PayloadItem previously produced by ToData. It is only
+	// ever called for items whose encoding metadata matches Encoding().
+	FromData(item *commonpb.PayloadItem, valuePtr interface{}) error
+	// Encoding returns the encoding metadata tag this converter reads and writes.
+	Encoding() string
+}
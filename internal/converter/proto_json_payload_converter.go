@@ -0,0 +1,56 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const encodingProtoJSON = "json/protobuf"
+
+// ProtoJSONPayloadConverter converts proto.Message values to/from their JSON wire format using
+// jsonpb, which honors proto field names and well-known-type mappings that encoding/json does
+// not. Like ProtoPayloadConverter, it only accepts proto.Message values.
+type ProtoJSONPayloadConverter struct{}
+
+// NewProtoJSONPayloadConverter creates a new ProtoJSONPayloadConverter.
+func NewProtoJSONPayloadConverter() *ProtoJSONPayloadConverter {
+	return &ProtoJSONPayloadConverter{}
+}
+
+// ToData implements PayloadConverter.ToData.
+func (c *ProtoJSONPayloadConverter) ToData(value interface{}) (*commonpb.PayloadItem, bool, error) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, false, nil
+	}
+	data, err := (&jsonpb.Marshaler{}).MarshalToString(msg)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+	}
+	return &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding: []byte(c.Encoding()),
+		},
+		Data: []byte(data),
+	}, true, nil
+}
+
+// FromData implements PayloadConverter.FromData.
+func (c *ProtoJSONPayloadConverter) FromData(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	msg, ok := valuePtr.(proto.Message)
+	if !ok {
+		return ErrValueDoesntImplementProtoMessage
+	}
+	if err := jsonpb.UnmarshalString(string(item.GetData()), msg); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// Encoding implements PayloadConverter.Encoding.
+func (c *ProtoJSONPayloadConverter) Encoding() string {
+	return encodingProtoJSON
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const encodingProto = "binary/protobuf"
+
+// ProtoPayloadConverter converts proto.Message values to/from their binary wire format. It
+// only accepts values implementing proto.Message; everything else is left for the next
+// converter in the chain.
+type ProtoPayloadConverter struct{}
+
+// NewProtoPayloadConverter creates a new ProtoPayloadConverter.
+func NewProtoPayloadConverter() *ProtoPayloadConverter {
+	return &ProtoPayloadConverter{}
+}
+
+// ToData implements PayloadConverter.ToData.
+func (c *ProtoPayloadConverter) ToData(value interface{}) (*commonpb.PayloadItem, bool, error) {
+	msg, ok := value.(proto.Message)
+	if !ok {
+		return nil, false, nil
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+	}
+	return &commonpb.PayloadItem{
+		Metadata: map[string][]byte{
+			MetadataEncoding: []byte(c.Encoding()),
+		},
+		Data: data,
+	}, true, nil
+}
+
+// FromData implements PayloadConverter.FromData.
+func (c *ProtoPayloadConverter) FromData(item *commonpb.PayloadItem, valuePtr interface{}) error {
+	msg, ok := valuePtr.(proto.Message)
+	if !ok {
+		return ErrValueDoesntImplementProtoMessage
+	}
+	if err := proto.Unmarshal(item.GetData(), msg); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+	}
+	return nil
+}
+
+// Encoding implements PayloadConverter.Encoding.
+func (c *ProtoPayloadConverter) Encoding() string {
+	return encodingProto
+}
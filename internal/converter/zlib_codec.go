@@ -0,0 +1,97 @@
+package converter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io/ioutil"
+
+	commonpb "go.temporal.io/temporal-proto/common"
+)
+
+const (
+	// metadataEncodingZlib is the encoding tag stamped on items compressed by ZlibCodec.
+	metadataEncodingZlib = "encoding/zlib"
+	// metadataEncodingZlibOriginal preserves the item's pre-compression encoding tag so Decode
+	// can hand it back to the DataConverter that owns that encoding.
+	metadataEncodingZlibOriginal = "encoding/zlib-original-encoding"
+
+	// defaultZlibMinBytes is the default size threshold below which ZlibCodec leaves items
+	// uncompressed, since zlib's own framing overhead outweighs the savings on small payloads.
+	defaultZlibMinBytes = 128
+)
+
+// ZlibCodec is a PayloadCodec that compresses PayloadItems larger than MinBytes with zlib.
+// Items at or below the threshold are passed through unmodified.
+type ZlibCodec struct {
+	// MinBytes is the size threshold, in encoded bytes, above which an item is compressed.
+	MinBytes int
+}
+
+var _ PayloadCodec = (*ZlibCodec)(nil)
+
+// NewZlibCodec creates a ZlibCodec that compresses items larger than minBytes. A minBytes of 0
+// selects defaultZlibMinBytes.
+func NewZlibCodec(minBytes int) *ZlibCodec {
+	if minBytes <= 0 {
+		minBytes = defaultZlibMinBytes
+	}
+	return &ZlibCodec{MinBytes: minBytes}
+}
+
+// Encode implements PayloadCodec.Encode.
+func (c *ZlibCodec) Encode(items []*commonpb.PayloadItem) ([]*commonpb.PayloadItem, error) {
+	result := make([]*commonpb.PayloadItem, len(items))
+	for i, item := range items {
+		if len(item.GetData()) <= c.MinBytes {
+			result[i] = item
+			continue
+		}
+
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		if _, err := w.Write(item.GetData()); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnableToEncode, err)
+		}
+
+		result[i] = &commonpb.PayloadItem{
+			Metadata: map[string][]byte{
+				MetadataEncoding:             []byte(metadataEncodingZlib),
+				metadataEncodingZlibOriginal: item.GetMetadata()[MetadataEncoding],
+			},
+			Data: buf.Bytes(),
+		}
+	}
+	return result, nil
+}
+
+// Decode implements PayloadCodec.Decode.
+func (c *ZlibCodec) Decode(items []*commonpb.PayloadItem) ([]*commonpb.PayloadItem, error) {
+	result := make([]*commonpb.PayloadItem, len(items))
+	for i, item := range items {
+		if string(item.GetMetadata()[MetadataEncoding]) != metadataEncodingZlib {
+			result[i] = item
+			continue
+		}
+
+		r, err := zlib.NewReader(bytes.NewReader(item.GetData()))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+		}
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnableToDecode, err)
+		}
+
+		result[i] = &commonpb.PayloadItem{
+			Metadata: map[string][]byte{
+				MetadataEncoding: item.GetMetadata()[metadataEncodingZlibOriginal],
+			},
+			Data: data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,267 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.temporal.io/temporal/internal/common/metrics"
+)
+
+// DefaultDeadlockDetectionThreshold is how long a decision task may hold the workflow goroutine
+// between yield points (ExecuteActivity, NewTimer, Sleep, ...) before the WorkflowInterceptorFactory
+// returned by NewDeadlockDetectorInterceptorFactory reports it as stuck, when that factory is given
+// a threshold of zero or less. Mirrors the threshold
+// Temporal server uses to ping its own shard controller goroutines.
+const DefaultDeadlockDetectionThreshold = time.Second
+
+// pingableGoroutine is the client-side analog of the pingable goroutine Temporal server uses to
+// watch its shard controllers. Unlike the server's, it spends most of its life paused: the
+// workflow goroutine only ever runs deterministic, CPU-bound code between two yield points
+// (ExecuteActivity, NewTimer, Sleep, ... returning control to the dispatcher so it can wait on
+// the real activity/timer/signal), and that in-between stretch is the only span that should ever
+// take workflow-scale real time. resume() arms the watchdog for one such stretch; pause() disarms
+// it again before a call that is expected to block for a long, caller-controlled duration. Without
+// that distinction, a single threshold compared against wall-clock time since the goroutine was
+// last entered cannot tell "still waiting on the timer it started" apart from "genuinely stuck."
+type pingableGoroutine struct {
+	threshold time.Duration
+	lastArm   int64 // unix nano of the last resume(), accessed atomically
+	pausedAt  int64 // unix nano of the last pause(), accessed atomically
+	armed     int32 // 0 while paused; the ticker skips the stall check entirely, accessed atomically
+	reported  int32 // 1 once onStall has fired for the current armed stretch, accessed atomically
+	stopCh    chan struct{}
+}
+
+func newPingableGoroutine(threshold time.Duration) *pingableGoroutine {
+	return &pingableGoroutine{
+		threshold: threshold,
+		lastArm:   time.Now().UnixNano(),
+		armed:     1,
+		stopCh:    make(chan struct{}),
+	}
+}
+
+// resume arms the watchdog from now, for the stretch of CPU-bound workflow code about to run
+// until the next yield point.
+func (p *pingableGoroutine) resume() {
+	atomic.StoreInt64(&p.lastArm, time.Now().UnixNano())
+	atomic.StoreInt32(&p.reported, 0)
+	atomic.StoreInt32(&p.armed, 1)
+}
+
+// pause disarms the watchdog because the goroutine is about to yield and block on something
+// whose duration the SDK does not control - an activity, a timer, or a signal - so elapsed real
+// time from here on is not evidence of a stall.
+func (p *pingableGoroutine) pause() {
+	atomic.StoreInt64(&p.pausedAt, time.Now().UnixNano())
+	atomic.StoreInt32(&p.armed, 0)
+}
+
+// start runs onStall at most once per armed stretch, checking roughly 4 times per threshold,
+// until the watchdog is stopped, or it has been paused (rather than stalled while armed) for
+// long enough that the workflow has almost certainly completed without anyone calling stop - the
+// pause case is the common one, since a workflow's last SDK call is typically one that pauses the
+// watchdog and is never followed by a resume.
+func (p *pingableGoroutine) start(onStall func()) {
+	go func() {
+		interval := p.threshold / 4
+		if interval <= 0 {
+			interval = time.Millisecond
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		const maxConsecutiveQuiet = 40 // ~10x threshold of silence; assume the workflow finished
+		consecutiveQuiet := 0
+		for {
+			select {
+			case <-p.stopCh:
+				return
+			case <-ticker.C:
+				if atomic.LoadInt32(&p.armed) == 0 {
+					if time.Since(time.Unix(0, atomic.LoadInt64(&p.pausedAt))) > p.threshold {
+						consecutiveQuiet++
+						if consecutiveQuiet > maxConsecutiveQuiet {
+							return
+						}
+					}
+					continue
+				}
+				consecutiveQuiet = 0
+				since := time.Since(time.Unix(0, atomic.LoadInt64(&p.lastArm)))
+				if since <= p.threshold {
+					continue
+				}
+				if atomic.CompareAndSwapInt32(&p.reported, 0, 1) {
+					onStall()
+				}
+			}
+		}
+	}()
+}
+
+func (p *pingableGoroutine) stop() {
+	close(p.stopCh)
+}
+
+// DeadlockError is returned by Sleep of the deadlock detector installed by
+// NewDeadlockDetectorInterceptorFactory when the detector observed the workflow goroutine running
+// past its threshold without yielding since the previous intercepted call. The other intercepted
+// calls (ExecuteActivity, NewTimer, SideEffect, ...) clear such a stall without returning it; in
+// every case the stall is still logged and counted when it is observed.
+type DeadlockError struct {
+	Threshold time.Duration
+}
+
+func (e *DeadlockError) Error() string {
+	return fmt.Sprintf("workflow goroutine did not yield within the configured deadlock detection threshold of %s", e.Threshold)
+}
+
+// deadlockInterceptor watches how long the workflow goroutine spends running CPU-bound code
+// between the points where it yields control back to the dispatcher: ExecuteActivity,
+// ExecuteLocalActivity, NewTimer, Sleep, and GetSignalChannel pause the watchdog for the
+// duration of the call and resume it on return, since those are expected to legitimately block
+// for workflow-scale time waiting on a real activity, timer, or signal; SideEffect and
+// MutableSideEffect stay armed throughout, since they are synchronous and must be fast.
+type deadlockInterceptor struct {
+	WorkflowInterceptorBase
+	watchdog *pingableGoroutine
+	stalled  int32 // 1 once the watchdog has reported a stall, accessed atomically
+	lastCtx  atomic.Value
+}
+
+func (t *deadlockInterceptor) onStall() {
+	buf := make([]byte, 1<<16)
+	n := runtime.Stack(buf, true)
+	atomic.StoreInt32(&t.stalled, 1)
+
+	ctx, _ := t.lastCtx.Load().(Context)
+	if ctx == nil {
+		return
+	}
+	t.GetLogger(ctx).Error("Workflow decision task exceeded deadlock detection threshold.",
+		zap.Duration("Threshold", t.watchdog.threshold),
+		zap.String("Stack", string(buf[:n])))
+	t.GetMetricsScope(ctx).Counter(metrics.WorkflowTaskDeadlockCounter).Inc(1)
+}
+
+// armed records that ctx made forward progress and returns a DeadlockError if a stall was
+// reported since the last time the watchdog was armed.
+func (t *deadlockInterceptor) armed(ctx Context) error {
+	t.lastCtx.Store(ctx)
+	t.watchdog.resume()
+	if atomic.CompareAndSwapInt32(&t.stalled, 1, 0) {
+		return &DeadlockError{Threshold: t.watchdog.threshold}
+	}
+	return nil
+}
+
+func (t *deadlockInterceptor) ExecuteActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	_ = t.armed(ctx)
+	t.watchdog.pause()
+	future := t.next.ExecuteActivity(ctx, activity, args...)
+	t.watchdog.resume()
+	return future
+}
+
+func (t *deadlockInterceptor) ExecuteLocalActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	_ = t.armed(ctx)
+	t.watchdog.pause()
+	future := t.next.ExecuteLocalActivity(ctx, activity, args...)
+	t.watchdog.resume()
+	return future
+}
+
+func (t *deadlockInterceptor) NewTimer(ctx Context, d time.Duration) Future {
+	_ = t.armed(ctx)
+	t.watchdog.pause()
+	future := t.next.NewTimer(ctx, d)
+	t.watchdog.resume()
+	return future
+}
+
+func (t *deadlockInterceptor) Sleep(ctx Context, d time.Duration) error {
+	armErr := t.armed(ctx)
+	t.watchdog.pause()
+	err := t.next.Sleep(ctx, d)
+	t.watchdog.resume()
+	if err != nil {
+		return err
+	}
+	return armErr
+}
+
+func (t *deadlockInterceptor) GetSignalChannel(ctx Context, signalName string) Channel {
+	_ = t.armed(ctx)
+	t.watchdog.pause()
+	ch := t.next.GetSignalChannel(ctx, signalName)
+	t.watchdog.resume()
+	return ch
+}
+
+func (t *deadlockInterceptor) SideEffect(ctx Context, f func(ctx Context) interface{}) Value {
+	_ = t.armed(ctx)
+	return t.next.SideEffect(ctx, f)
+}
+
+func (t *deadlockInterceptor) MutableSideEffect(ctx Context, id string, f func(ctx Context) interface{}, equals func(a, b interface{}) bool) Value {
+	_ = t.armed(ctx)
+	return t.next.MutableSideEffect(ctx, id, f, equals)
+}
+
+// NewDeadlockDetectorInterceptorFactory returns a WorkflowInterceptorFactory that logs a stack
+// dump and increments WorkflowTaskDeadlockCounter, and returns a DeadlockError from Sleep as
+// described on DeadlockError, if the workflow goroutine runs CPU-bound workflow code
+// for longer than threshold without yielding through one of ExecuteActivity,
+// ExecuteLocalActivity, NewTimer, Sleep, GetSignalChannel, SideEffect, or MutableSideEffect. Time
+// spent legitimately blocked inside the first five of those, waiting on a real activity, timer,
+// or signal, does not count against threshold - see deadlockInterceptor. A threshold of zero or
+// less selects DefaultDeadlockDetectionThreshold.
+//
+// NewInterceptor's returned watchdog goroutine is stopped via pingableGoroutine's own give-up
+// logic once the workflow appears to have gone quiet, since WorkflowInterceptor has no
+// completion hook in this tree to call pingableGoroutine.stop() from.
+func NewDeadlockDetectorInterceptorFactory(threshold time.Duration) WorkflowInterceptorFactory {
+	if threshold <= 0 {
+		threshold = DefaultDeadlockDetectionThreshold
+	}
+	return &deadlockInterceptorFactory{threshold: threshold}
+}
+
+type deadlockInterceptorFactory struct {
+	threshold time.Duration
+}
+
+func (f *deadlockInterceptorFactory) NewInterceptor(next WorkflowInterceptor) WorkflowInterceptor {
+	t := &deadlockInterceptor{WorkflowInterceptorBase: WorkflowInterceptorBase{next: next}, watchdog: newPingableGoroutine(f.threshold)}
+	t.watchdog.start(t.onStall)
+	return t
+}
@@ -0,0 +1,60 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingableGoroutine_PausedDoesNotStallWhilePaused(t *testing.T) {
+	const threshold = 20 * time.Millisecond
+	var stalls int32
+	watchdog := newPingableGoroutine(threshold)
+	watchdog.start(func() { atomic.AddInt32(&stalls, 1) })
+	defer watchdog.stop()
+
+	watchdog.pause()
+	// Longer than threshold, but paused: a real Sleep()/ExecuteActivity() of this length must
+	// not be reported as a stall.
+	time.Sleep(3 * threshold)
+	require.EqualValues(t, 0, atomic.LoadInt32(&stalls))
+}
+
+func TestPingableGoroutine_ArmedStallsAfterThreshold(t *testing.T) {
+	const threshold = 20 * time.Millisecond
+	var stalls int32
+	watchdog := newPingableGoroutine(threshold)
+	watchdog.start(func() { atomic.AddInt32(&stalls, 1) })
+	defer watchdog.stop()
+
+	watchdog.resume()
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&stalls) > 0
+	}, 3*threshold, threshold/4)
+}
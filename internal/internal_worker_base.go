@@ -30,6 +30,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"sync"
 	"time"
 
@@ -238,11 +239,37 @@ func (bw *baseWorker) runPoller() {
 			if bw.sessionTokenBucket != nil {
 				bw.sessionTokenBucket.waitForAvailableToken()
 			}
-			bw.pollTask()
+			bw.safePollTask()
 		}
 	}
 }
 
+// safePollTask wraps pollTask with panic recovery so that a panic inside a custom
+// taskPoller.PollTask implementation (or a codec used during unmarshaling) does not tear down
+// the poller goroutine and silently reduce concurrency until the worker is restarted.
+func (bw *baseWorker) safePollTask() {
+	defer func() {
+		if p := recover(); p != nil {
+			bw.metricsScope.Counter(metrics.PollerPanicCounter).Inc(1)
+			topLine := fmt.Sprintf("base worker for %s [panic]:", bw.options.workerType)
+			st := getStackTraceRaw(topLine, 7, 0)
+			bw.logger.Error("Unhandled panic in poller.",
+				zap.String("PanicError", fmt.Sprintf("%v", p)),
+				zap.String("PanicStack", st))
+			// Treat the panic like a failed poll so the retrier backs off, and return the
+			// token we borrowed so runTaskDispatcher keeps the poller pool at full strength.
+			bw.retrier.Failed()
+			bw.pollerRequestCh <- struct{}{}
+		}
+	}()
+	bw.pollTask()
+}
+
+// taskShardBufferSize bounds how many tasks a single shard will queue ahead of the goroutine
+// that drains it, giving processTask's caller somewhere to push to without blocking the
+// dispatcher while still capping per-shard memory.
+const taskShardBufferSize = 100
+
 func (bw *baseWorker) runTaskDispatcher() {
 	defer bw.stopWG.Done()
 
@@ -250,6 +277,17 @@ func (bw *baseWorker) runTaskDispatcher() {
 		bw.pollerRequestCh <- struct{}{}
 	}
 
+	shards := bw.startTaskShards()
+	// Closing every shard once this dispatcher stops routing new tasks lets each runTaskShard
+	// finish draining whatever is already buffered in its channel - via "range shard" - instead
+	// of racing stopCh against pending work and potentially discarding tasks that were already
+	// dequeued from taskQueueCh.
+	defer func() {
+		for _, shard := range shards {
+			close(shard)
+		}
+	}()
+
 	for {
 		// wait for new task or worker stop
 		select {
@@ -263,12 +301,86 @@ func (bw *baseWorker) runTaskDispatcher() {
 					return
 				}
 			}
-			bw.stopWG.Add(1)
-			go bw.processTask(task)
+			bw.metricsScope.Gauge(metrics.PoolQueueDepthGauge).Update(float64(len(bw.taskQueueCh)))
+			// No stopCh case here: this task is already dequeued, so it must be handed to its
+			// shard rather than dropped. The shard's buffer is drained by runTaskShard
+			// regardless of stop state, so this can't deadlock against Stop().
+			shard := shards[shardForTask(task, len(shards))]
+			shard <- task
 		}
 	}
 }
 
+// startTaskShards starts a fixed-size pool of shard goroutines, one per maxConcurrentTask slot,
+// and returns their input channels. Routing a task to a shard by hashing its affinity key (rather
+// than spawning an unbounded "go processTask" per task, as this worker used to) bounds the
+// goroutine count a task burst can create and guarantees FIFO ordering between tasks that share
+// a key, e.g. the decision tasks and local activity results belonging to the same workflow run.
+func (bw *baseWorker) startTaskShards() []chan interface{} {
+	shardCount := bw.options.maxConcurrentTask
+	if shardCount <= 0 {
+		shardCount = 1
+	}
+	shards := make([]chan interface{}, shardCount)
+	for i := range shards {
+		shard := make(chan interface{}, taskShardBufferSize)
+		shards[i] = shard
+		bw.stopWG.Add(1)
+		go bw.runTaskShard(shard)
+	}
+	return shards
+}
+
+// runTaskShard drains a single shard's channel on its own goroutine, processing tasks FIFO so
+// that ordering is preserved for everything routed to this shard. It does not select on stopCh:
+// runTaskDispatcher closes shard once it stops feeding it, so ranging over shard already exits
+// as soon as Stop is requested and every task buffered ahead of that point has run, instead of
+// racing stop against a buffer that can hold up to taskShardBufferSize pending tasks.
+func (bw *baseWorker) runTaskShard(shard chan interface{}) {
+	defer bw.stopWG.Done()
+	for task := range shard {
+		bw.metricsScope.Gauge(metrics.PoolShardBacklogGauge).Update(float64(len(shard)))
+		bw.processTask(task)
+	}
+}
+
+// shardForTask hashes a task's affinity key to a shard index, so tasks sharing a key (e.g. the
+// same workflow run) are always processed by the same shard goroutine and thus stay FIFO
+// relative to each other. Tasks with no meaningful affinity key fall back to shard 0.
+func shardForTask(task interface{}, shardCount int) int {
+	if shardCount <= 1 {
+		return 0
+	}
+	key := taskAffinityKey(task)
+	if key == "" {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// taskAffinityKey extracts the key used to shard a task: WorkflowID+RunID for decision tasks,
+// ActivityID for activity tasks, and the local activity's ID for local activity results.
+func taskAffinityKey(task interface{}) string {
+	if polled, ok := task.(*polledTask); ok {
+		task = polled.task
+	}
+	switch t := task.(type) {
+	case *workflowTask:
+		if we := t.task.GetWorkflowExecution(); we != nil {
+			return we.GetWorkflowId() + we.GetRunId()
+		}
+	case *activityTask:
+		if t.task != nil {
+			return t.task.GetActivityId()
+		}
+	case *localActivityTask:
+		return t.activityID
+	}
+	return ""
+}
+
 func (bw *baseWorker) pollTask() {
 	var err error
 	var task interface{}
@@ -296,7 +408,8 @@ func (bw *baseWorker) pollTask() {
 }
 
 func (bw *baseWorker) processTask(task interface{}) {
-	defer bw.stopWG.Done()
+	// Called from a shard goroutine started by startTaskShards, which owns this task's
+	// contribution to stopWG for its own lifetime, so processTask itself does not touch it.
 	// If the task is from poller, after processing it we would need to request a new poll. Otherwise, the task is from
 	// local activity worker, we don't need a new poll from server.
 	polledTask, isPolledTask := task.(*polledTask)
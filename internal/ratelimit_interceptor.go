@@ -0,0 +1,213 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.temporal.io/temporal/internal/common/metrics"
+)
+
+// ActivityRateLimitOptions configures the rate limiter and concurrency gate installed by
+// NewRateLimitInterceptorFactory.
+type ActivityRateLimitOptions struct {
+	// KeyFunc buckets ExecuteActivity/ExecuteLocalActivity calls for rate limiting and
+	// concurrency gating purposes. Defaults to the activity's registered name, so every call to
+	// the same activity function shares one bucket.
+	KeyFunc func(activity interface{}) string
+	// TokensPerSecond is the sustained token-bucket refill rate per key. Zero disables rate
+	// limiting.
+	TokensPerSecond float64
+	// BucketSize is the maximum burst of calls a key may make before it starts waiting for
+	// refill. Defaults to 1 if TokensPerSecond is set and BucketSize is zero.
+	BucketSize int
+	// MaxConcurrent caps the number of in-flight ExecuteActivity/ExecuteLocalActivity calls per
+	// key. Zero disables concurrency gating.
+	MaxConcurrent int
+}
+
+func (o *ActivityRateLimitOptions) keyFor(activity interface{}) string {
+	if o.KeyFunc != nil {
+		return o.KeyFunc(activity)
+	}
+	if name, ok := activity.(string); ok {
+		return name
+	}
+	fn := runtime.FuncForPC(reflect.ValueOf(activity).Pointer())
+	if fn == nil {
+		return fmt.Sprintf("%T", activity)
+	}
+	name := fn.Name()
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return name
+}
+
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// rateLimitInterceptor throttles ExecuteActivity/ExecuteLocalActivity calls with a per-key
+// token-bucket rate limit and/or a per-key max-in-flight semaphore, so a single workflow fanning
+// out many activities can't overrun a downstream system the way an unbounded loop of
+// ExecuteActivity calls would.
+type rateLimitInterceptor struct {
+	WorkflowInterceptorBase
+	options ActivityRateLimitOptions
+	buckets map[string]*tokenBucket
+	gates   map[string]Channel
+	callSeq int
+}
+
+// NewRateLimitInterceptorFactory returns a WorkflowInterceptorFactory that enforces options on
+// every ExecuteActivity/ExecuteLocalActivity call made by the workflow.
+func NewRateLimitInterceptorFactory(options ActivityRateLimitOptions) WorkflowInterceptorFactory {
+	return &rateLimitInterceptorFactory{options: options}
+}
+
+type rateLimitInterceptorFactory struct {
+	options ActivityRateLimitOptions
+}
+
+func (f *rateLimitInterceptorFactory) NewInterceptor(next WorkflowInterceptor) WorkflowInterceptor {
+	return &rateLimitInterceptor{
+		WorkflowInterceptorBase: WorkflowInterceptorBase{next: next},
+		options:                 f.options,
+		buckets:                 make(map[string]*tokenBucket),
+		gates:                   make(map[string]Channel),
+	}
+}
+
+// waitForToken blocks ctx's workflow goroutine until key's token bucket has a token to spend, at
+// most once per call. The wait is computed from the workflow clock and pinned in history via
+// MutableSideEffect, so replay reproduces the exact same wait even if TokensPerSecond or
+// BucketSize changes before replay runs.
+func (t *rateLimitInterceptor) waitForToken(ctx Context, key string) {
+	if t.options.TokensPerSecond <= 0 {
+		return
+	}
+	bucketSize := t.options.BucketSize
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+	bucket, ok := t.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(bucketSize), lastRefill: t.Now(ctx)}
+		t.buckets[key] = bucket
+	}
+
+	now := t.Now(ctx)
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens = minFloat64(float64(bucketSize), bucket.tokens+elapsed*t.options.TokensPerSecond)
+	bucket.lastRefill = now
+
+	var wait time.Duration
+	if bucket.tokens < 1 {
+		wait = time.Duration((1 - bucket.tokens) / t.options.TokensPerSecond * float64(time.Second))
+	}
+
+	t.callSeq++
+	mutableSideEffectID := fmt.Sprintf("ratelimit-wait-%d", t.callSeq)
+	pinnedWait := t.MutableSideEffect(ctx, mutableSideEffectID, func(ctx Context) interface{} {
+		return int64(wait)
+	}, func(a, b interface{}) bool {
+		return a.(int64) == b.(int64)
+	})
+	var pinnedNanos int64
+	_ = pinnedWait.Get(&pinnedNanos)
+	wait = time.Duration(pinnedNanos)
+
+	if wait > 0 {
+		t.GetMetricsScope(ctx).Counter(metrics.ActivityRateLimitThrottledCounter).Inc(1)
+		_ = t.NewTimer(ctx, wait).Get(ctx, nil)
+		bucket.lastRefill = t.Now(ctx)
+		bucket.tokens = 1
+	}
+	bucket.tokens--
+}
+
+// acquire blocks until a concurrency slot for key is free, returning the release function.
+func (t *rateLimitInterceptor) acquire(ctx Context, key string) func() {
+	if t.options.MaxConcurrent <= 0 {
+		return func() {}
+	}
+	gate, ok := t.gates[key]
+	if !ok {
+		gate = NewBufferedChannel(ctx, t.options.MaxConcurrent)
+		t.gates[key] = gate
+	}
+	if !gate.SendAsync(struct{}{}) {
+		t.GetMetricsScope(ctx).Counter(metrics.ActivityConcurrencyBlockedCounter).Inc(1)
+		gate.Send(ctx, struct{}{})
+	}
+	return func() {
+		var token interface{}
+		gate.ReceiveAsync(&token)
+	}
+}
+
+func (t *rateLimitInterceptor) ExecuteActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	return t.gate(ctx, activity, func(ctx Context) Future {
+		return t.next.ExecuteActivity(ctx, activity, args...)
+	})
+}
+
+func (t *rateLimitInterceptor) ExecuteLocalActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	return t.gate(ctx, activity, func(ctx Context) Future {
+		return t.next.ExecuteLocalActivity(ctx, activity, args...)
+	})
+}
+
+// gate enforces the rate limit and concurrency cap for activity before calling attempt, releasing
+// the concurrency slot as soon as attempt's Future completes rather than when gate itself
+// returns, so callers still get a Future they can wait on concurrently with other work.
+func (t *rateLimitInterceptor) gate(ctx Context, activity interface{}, attempt func(ctx Context) Future) Future {
+	key := t.options.keyFor(activity)
+	t.waitForToken(ctx, key)
+	release := t.acquire(ctx, key)
+
+	future, settable := NewFuture(ctx)
+	Go(ctx, func(ctx Context) {
+		defer release()
+		inner := attempt(ctx)
+		var result interface{}
+		err := inner.Get(ctx, &result)
+		settable.Set(result, err)
+	})
+	return future
+}
+
+func minFloat64(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
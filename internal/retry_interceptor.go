@@ -0,0 +1,190 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"fmt"
+
+	"go.temporal.io/temporal/internal/common/backoff"
+)
+
+// IsTransientError classifies whether err should be retried by the interceptor returned from
+// NewRetryInterceptorFactory. It is called with the error ExecuteActivity, ExecuteLocalActivity,
+// ExecuteChildWorkflow, SignalExternalWorkflow, or RequestCancelExternalWorkflow completed with.
+type IsTransientError func(err error) bool
+
+// retryInterceptor retries ExecuteActivity, ExecuteLocalActivity, ExecuteChildWorkflow,
+// SignalExternalWorkflow, and RequestCancelExternalWorkflow at the workflow layer, on top of
+// whatever retry policy the server applies to the activity itself. This lets callers retry child
+// workflows and signal/cancel RPCs, neither of which the server retries on its own.
+type retryInterceptor struct {
+	WorkflowInterceptorBase
+	policy      backoff.RetryPolicy
+	isTransient IsTransientError
+	callSeq     int
+}
+
+// NewRetryInterceptorFactory returns a WorkflowInterceptorFactory that retries the calls listed
+// on retryInterceptor according to policy, for as long as isTransient classifies the returned
+// error as transient. Retries are driven entirely off the workflow clock (Now/NewTimer), so they
+// replay deterministically regardless of how long policy's intervals are.
+func NewRetryInterceptorFactory(policy backoff.RetryPolicy, isTransient IsTransientError) WorkflowInterceptorFactory {
+	return &retryInterceptorFactory{policy: policy, isTransient: isTransient}
+}
+
+type retryInterceptorFactory struct {
+	policy      backoff.RetryPolicy
+	isTransient IsTransientError
+}
+
+func (f *retryInterceptorFactory) NewInterceptor(next WorkflowInterceptor) WorkflowInterceptor {
+	return &retryInterceptor{
+		WorkflowInterceptorBase: WorkflowInterceptorBase{next: next},
+		policy:                  f.policy,
+		isTransient:             f.isTransient,
+	}
+}
+
+// nextMutableSideEffectID returns a new, deterministic ID for pinning this call's attempt count
+// in history via MutableSideEffect. Call order inside a workflow is itself deterministic, so a
+// per-instance counter is a stable key across replay.
+func (t *retryInterceptor) nextMutableSideEffectID() string {
+	t.callSeq++
+	return fmt.Sprintf("retry-attempt-%d", t.callSeq)
+}
+
+// runWithRetry calls attempt until it succeeds, isTransient stops classifying its error as
+// retryable, or policy runs out of retries, and reports the outcome through settable. The attempt
+// number actually used is pinned in history via MutableSideEffect; whether to retry and how long
+// to wait are recomputed from isTransient and policy on every run, including replay, and are not
+// recorded.
+func (t *retryInterceptor) runWithRetry(ctx Context, settable Settable, attempt func(ctx Context) Future) {
+	mutableSideEffectID := t.nextMutableSideEffectID()
+	startTime := t.Now(ctx)
+
+	for attemptCount := 1; ; attemptCount++ {
+		recordedAttempt := t.MutableSideEffect(ctx, mutableSideEffectID, func(ctx Context) interface{} {
+			return attemptCount
+		}, func(a, b interface{}) bool {
+			return a.(int) == b.(int)
+		})
+		var recorded int
+		if err := recordedAttempt.Get(&recorded); err == nil {
+			attemptCount = recorded
+		}
+
+		future := attempt(ctx)
+		var result interface{}
+		err := future.Get(ctx, &result)
+		if err == nil || !t.isTransient(err) {
+			settable.Set(result, err)
+			return
+		}
+
+		delay := t.policy.ComputeNextDelay(t.Now(ctx).Sub(startTime), attemptCount)
+		if delay < 0 {
+			settable.Set(result, err)
+			return
+		}
+		if timerErr := t.NewTimer(ctx, delay).Get(ctx, nil); timerErr != nil {
+			settable.Set(result, timerErr)
+			return
+		}
+	}
+}
+
+func (t *retryInterceptor) ExecuteActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	future, settable := NewFuture(ctx)
+	Go(ctx, func(ctx Context) {
+		t.runWithRetry(ctx, settable, func(ctx Context) Future {
+			return t.next.ExecuteActivity(ctx, activity, args...)
+		})
+	})
+	return future
+}
+
+func (t *retryInterceptor) ExecuteLocalActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	future, settable := NewFuture(ctx)
+	Go(ctx, func(ctx Context) {
+		t.runWithRetry(ctx, settable, func(ctx Context) Future {
+			return t.next.ExecuteLocalActivity(ctx, activity, args...)
+		})
+	})
+	return future
+}
+
+func (t *retryInterceptor) SignalExternalWorkflow(ctx Context, workflowID, runID, signalName string, arg interface{}) Future {
+	future, settable := NewFuture(ctx)
+	Go(ctx, func(ctx Context) {
+		t.runWithRetry(ctx, settable, func(ctx Context) Future {
+			return t.next.SignalExternalWorkflow(ctx, workflowID, runID, signalName, arg)
+		})
+	})
+	return future
+}
+
+func (t *retryInterceptor) RequestCancelExternalWorkflow(ctx Context, workflowID, runID string) Future {
+	future, settable := NewFuture(ctx)
+	Go(ctx, func(ctx Context) {
+		t.runWithRetry(ctx, settable, func(ctx Context) Future {
+			return t.next.RequestCancelExternalWorkflow(ctx, workflowID, runID)
+		})
+	})
+	return future
+}
+
+// retryingChildWorkflowFuture wraps the ChildWorkflowFuture of whichever attempt is currently in
+// flight so that GetChildWorkflowExecution keeps returning the execution of the attempt that is
+// actually running, rather than the first attempt's, once ExecuteChildWorkflow has retried past
+// it. execution is reassigned synchronously at the start of every attempt; workflow goroutines are
+// scheduled cooperatively (never concurrently), so this needs no lock.
+type retryingChildWorkflowFuture struct {
+	Future
+	execution Future
+}
+
+func (f *retryingChildWorkflowFuture) GetChildWorkflowExecution() Future {
+	return f.execution
+}
+
+func (t *retryInterceptor) ExecuteChildWorkflow(ctx Context, childWorkflow interface{}, args ...interface{}) ChildWorkflowFuture {
+	future, settable := NewFuture(ctx)
+	result := &retryingChildWorkflowFuture{Future: future}
+	Go(ctx, func(ctx Context) {
+		t.runWithRetry(ctx, settable, func(ctx Context) Future {
+			childFuture := t.next.ExecuteChildWorkflow(ctx, childWorkflow, args...)
+
+			executionFuture, executionSettable := NewFuture(ctx)
+			result.execution = executionFuture
+			Go(ctx, func(ctx Context) {
+				var execution interface{}
+				executionSettable.Set(execution, childFuture.GetChildWorkflowExecution().Get(ctx, &execution))
+			})
+
+			return childFuture
+		})
+	})
+	return result
+}
@@ -0,0 +1,158 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"context"
+	"sync"
+)
+
+// Tracer is the minimal abstraction TracingContextPropagator and
+// NewTracingInterceptorFactory need from an underlying tracing library. NewOpenTracingTracer
+// and NewOpenTelemetryTracer adapt the two most common libraries; implement Tracer directly to
+// plug in another one.
+type Tracer interface {
+	// StartSpan starts a new span named operationName, parented to the span encoded in header
+	// if one is present and otherwise to the span active in ctx, if any, and returns the context
+	// to continue with plus a function to call when the span ends.
+	StartSpan(ctx context.Context, operationName string, header HeaderReader) (spanCtx context.Context, finish func(err error))
+	// Inject serializes the span active in ctx, if any, into header. It is a no-op if ctx
+	// carries no span.
+	Inject(ctx context.Context, header HeaderWriter) error
+}
+
+// TracingContextPropagator is a ContextPropagator that carries the active tracing span across
+// the client/worker boundary on top of the standard Header machinery, so a Tracer's spans
+// survive a trip through the Temporal server. Its only span is the one Extract starts, parented
+// to the header's span, and finishes at once so the returned context carries it; see
+// NewTracingInterceptorFactory for a WorkflowInterceptor that starts child spans around
+// ExecuteActivity and ExecuteChildWorkflow using the propagated parent.
+type TracingContextPropagator struct {
+	tracer Tracer
+}
+
+var _ ContextPropagator = (*TracingContextPropagator)(nil)
+
+// NewTracingContextPropagator creates a ContextPropagator that injects/extracts spans from
+// tracer across the wire.
+func NewTracingContextPropagator(tracer Tracer) *TracingContextPropagator {
+	return &TracingContextPropagator{tracer: tracer}
+}
+
+// Inject implements ContextPropagator.Inject.
+func (p *TracingContextPropagator) Inject(ctx context.Context, writer HeaderWriter) error {
+	return p.tracer.Inject(ctx, writer)
+}
+
+// InjectFromWorkflow implements ContextPropagator.InjectFromWorkflow. Workflow code executes
+// deterministically and outside of a real context.Context, so there is no span to propagate
+// from a workflow goroutine and this is a no-op.
+func (p *TracingContextPropagator) InjectFromWorkflow(ctx Context, writer HeaderWriter) error {
+	return nil
+}
+
+// Extract implements ContextPropagator.Extract.
+func (p *TracingContextPropagator) Extract(ctx context.Context, reader HeaderReader) (context.Context, error) {
+	spanCtx, finish := p.tracer.StartSpan(ctx, "ContextPropagation", reader)
+	finish(nil)
+	return spanCtx, nil
+}
+
+// ExtractToWorkflow implements ContextPropagator.ExtractToWorkflow. There is no workflow context
+// equivalent of a tracing span - this snapshot has no Context.WithValue to stash one in - so the
+// workflow Context is returned unchanged. tracingWorkflowInterceptor does not consume the
+// caller-propagated parent span from header either; see the root span note on
+// tracingWorkflowInterceptor for what it does instead.
+func (p *TracingContextPropagator) ExtractToWorkflow(ctx Context, reader HeaderReader) (Context, error) {
+	return ctx, nil
+}
+
+// tracingWorkflowInterceptor starts a child span for every ExecuteActivity/ExecuteChildWorkflow
+// call while the workflow is not replaying, so history replay never produces duplicate spans.
+// All of a run's activity/child-workflow spans are parented to one root span for the run,
+// started lazily from the first such call, rather than each being its own disconnected root -
+// that's what links them together as a single trace. The root span is started from
+// context.Background() rather than a caller-propagated parent: doing the latter would mean
+// threading the span extracted by TracingContextPropagator.ExtractToWorkflow through the
+// workflow Context, which needs a Context.WithValue this snapshot doesn't have. The root span is
+// also never finished, since WorkflowInterceptor has no workflow-completion hook to call finish
+// from; a real build of this interceptor needs one.
+type tracingWorkflowInterceptor struct {
+	WorkflowInterceptorBase
+	tracer Tracer
+
+	rootOnce sync.Once
+	rootCtx  context.Context
+}
+
+// rootSpanContext returns the context carrying this run's root span, starting it on first use.
+func (t *tracingWorkflowInterceptor) rootSpanContext() context.Context {
+	t.rootOnce.Do(func() {
+		t.rootCtx, _ = t.tracer.StartSpan(context.Background(), "RunWorkflow", nil)
+	})
+	return t.rootCtx
+}
+
+// NewTracingInterceptorFactory returns a WorkflowInterceptorFactory that wraps ExecuteActivity
+// and ExecuteChildWorkflow with a child span of the run's root span. The root span is not linked
+// to any span TracingContextPropagator propagated into the workflow's header - see
+// tracingWorkflowInterceptor.
+func NewTracingInterceptorFactory(tracer Tracer) WorkflowInterceptorFactory {
+	return &tracingInterceptorFactory{tracer: tracer}
+}
+
+type tracingInterceptorFactory struct {
+	tracer Tracer
+}
+
+func (f *tracingInterceptorFactory) NewInterceptor(next WorkflowInterceptor) WorkflowInterceptor {
+	return &tracingWorkflowInterceptor{WorkflowInterceptorBase: WorkflowInterceptorBase{next: next}, tracer: f.tracer}
+}
+
+// ExecuteActivity starts a span covering the scheduling of the activity, as a child of this run's
+// root span (see rootSpanContext) rather than a new root, so every activity the workflow
+// schedules is linked into one trace together. It does NOT link to any span the activity worker
+// itself starts for the actual, possibly long-running, activity execution: that would need
+// injecting this span into the outgoing header for the activity worker to extract, which neither
+// this method nor TracingContextPropagator does.
+func (t *tracingWorkflowInterceptor) ExecuteActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	if t.IsReplaying(ctx) {
+		return t.next.ExecuteActivity(ctx, activity, args...)
+	}
+	_, finish := t.tracer.StartSpan(t.rootSpanContext(), "ExecuteActivity", nil)
+	defer finish(nil)
+	return t.next.ExecuteActivity(ctx, activity, args...)
+}
+
+// ExecuteChildWorkflow starts a span covering the scheduling of the child workflow, for the
+// same reason documented on ExecuteActivity.
+func (t *tracingWorkflowInterceptor) ExecuteChildWorkflow(ctx Context, childWorkflow interface{}, args ...interface{}) ChildWorkflowFuture {
+	if t.IsReplaying(ctx) {
+		return t.next.ExecuteChildWorkflow(ctx, childWorkflow, args...)
+	}
+	_, finish := t.tracer.StartSpan(t.rootSpanContext(), "ExecuteChildWorkflow", nil)
+	defer finish(nil)
+	return t.next.ExecuteChildWorkflow(ctx, childWorkflow, args...)
+}
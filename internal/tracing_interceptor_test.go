@@ -0,0 +1,86 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// terminalWorkflowInterceptor is a WorkflowInterceptor that never forwards, so tests can exercise
+// tracingWorkflowInterceptor without needing a full implementation of every method.
+type terminalWorkflowInterceptor struct {
+	WorkflowInterceptorBase
+	replaying bool
+}
+
+func (t *terminalWorkflowInterceptor) IsReplaying(ctx Context) bool {
+	return t.replaying
+}
+
+func (t *terminalWorkflowInterceptor) ExecuteActivity(ctx Context, activity interface{}, args ...interface{}) Future {
+	return nil
+}
+
+func (t *terminalWorkflowInterceptor) ExecuteChildWorkflow(ctx Context, childWorkflow interface{}, args ...interface{}) ChildWorkflowFuture {
+	return nil
+}
+
+// recordingTracer records the parent context.Context each StartSpan call was given, so a test can
+// assert that two spans share a parent instead of each being its own root.
+type recordingTracer struct {
+	parents []context.Context
+}
+
+func (r *recordingTracer) StartSpan(ctx context.Context, operationName string, header HeaderReader) (context.Context, func(err error)) {
+	r.parents = append(r.parents, ctx)
+	spanCtx := context.WithValue(ctx, recordingTracerSpanKey{}, len(r.parents))
+	return spanCtx, func(err error) {}
+}
+
+func (r *recordingTracer) Inject(ctx context.Context, header HeaderWriter) error {
+	return nil
+}
+
+type recordingTracerSpanKey struct{}
+
+func TestTracingWorkflowInterceptor_SharesOneRootAcrossCalls(t *testing.T) {
+	tracer := &recordingTracer{}
+	factory := NewTracingInterceptorFactory(tracer)
+	interceptor := factory.NewInterceptor(&terminalWorkflowInterceptor{}).(*tracingWorkflowInterceptor)
+
+	interceptor.ExecuteActivity(nil, "activity1")
+	interceptor.ExecuteChildWorkflow(nil, "workflow1")
+
+	// First call: root span, ExecuteActivity span.
+	// Second call: root span is reused (rootOnce), ExecuteChildWorkflow span.
+	require.Len(t, tracer.parents, 3)
+	root := tracer.parents[0]
+	require.Equal(t, context.Background(), root)
+	require.Equal(t, interceptor.rootCtx, tracer.parents[1])
+	require.Equal(t, interceptor.rootCtx, tracer.parents[2])
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+
+	"go.temporal.io/temporal/internal"
+)
+
+// headerCarrier adapts internal.HeaderReader/HeaderWriter to OpenTelemetry's
+// propagation.HTTPSupplier, the carrier interface its TextMapPropagator implementations read
+// and write through.
+type headerCarrier struct {
+	reader internal.HeaderReader
+	writer internal.HeaderWriter
+	values map[string]string
+}
+
+func (c *headerCarrier) Get(key string) string {
+	if c.values == nil {
+		c.values = make(map[string]string)
+		if c.reader != nil {
+			_ = c.reader.ForEachKey(func(key string, val []byte) error {
+				c.values[key] = string(val)
+				return nil
+			})
+		}
+	}
+	return c.values[key]
+}
+
+func (c *headerCarrier) Set(key, value string) {
+	c.writer.Set(key, []byte(value))
+}
+
+// openTelemetryTracer adapts an OpenTelemetry trace.Tracer and propagation.TextMapPropagator to
+// internal.Tracer.
+type openTelemetryTracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewOpenTelemetryTracer adapts tracer and propagator (e.g. from an OTLP, Jaeger, or Zipkin
+// exporter) to the internal.Tracer interface NewContextPropagator and
+// NewWorkflowInterceptorFactory need.
+func NewOpenTelemetryTracer(tracer trace.Tracer, propagator propagation.TextMapPropagator) internal.Tracer {
+	return &openTelemetryTracer{tracer: tracer, propagator: propagator}
+}
+
+func (t *openTelemetryTracer) StartSpan(ctx context.Context, operationName string, header internal.HeaderReader) (context.Context, func(err error)) {
+	if header != nil {
+		ctx = t.propagator.Extract(ctx, &headerCarrier{reader: header})
+	}
+	spanCtx, span := t.tracer.Start(ctx, operationName)
+	return spanCtx, func(err error) {
+		if err != nil {
+			span.RecordError(ctx, err)
+		}
+		span.End()
+	}
+}
+
+func (t *openTelemetryTracer) Inject(ctx context.Context, header internal.HeaderWriter) error {
+	t.propagator.Inject(ctx, &headerCarrier{writer: header})
+	return nil
+}
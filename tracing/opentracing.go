@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+
+	"go.temporal.io/temporal/internal"
+)
+
+// openTracingTracer adapts an opentracing.Tracer to internal.Tracer.
+type openTracingTracer struct {
+	tracer opentracing.Tracer
+}
+
+// NewOpenTracingTracer adapts tracer (e.g. Jaeger or Zipkin's opentracing.Tracer
+// implementation) to the internal.Tracer interface NewContextPropagator and
+// NewWorkflowInterceptorFactory need.
+func NewOpenTracingTracer(tracer opentracing.Tracer) internal.Tracer {
+	return &openTracingTracer{tracer: tracer}
+}
+
+func (t *openTracingTracer) StartSpan(ctx context.Context, operationName string, header internal.HeaderReader) (context.Context, func(err error)) {
+	var opts []opentracing.StartSpanOption
+	if header != nil {
+		carrier := make(opentracing.TextMapCarrier)
+		_ = header.ForEachKey(func(key string, val []byte) error {
+			carrier.Set(key, string(val))
+			return nil
+		})
+		if parent, err := t.tracer.Extract(opentracing.TextMap, carrier); err == nil {
+			opts = append(opts, opentracing.ChildOf(parent))
+		}
+	}
+	if len(opts) == 0 {
+		if parent := opentracing.SpanFromContext(ctx); parent != nil {
+			opts = append(opts, opentracing.ChildOf(parent.Context()))
+		}
+	}
+
+	span := t.tracer.StartSpan(operationName, opts...)
+	spanCtx := opentracing.ContextWithSpan(ctx, span)
+	return spanCtx, func(err error) {
+		if err != nil {
+			span.SetTag("error", true)
+			span.LogKV("error.object", err)
+		}
+		span.Finish()
+	}
+}
+
+func (t *openTracingTracer) Inject(ctx context.Context, header internal.HeaderWriter) error {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return nil
+	}
+	carrier := make(opentracing.TextMapCarrier)
+	if err := t.tracer.Inject(span.Context(), opentracing.TextMap, carrier); err != nil {
+		return err
+	}
+	return carrier.ForeachKey(func(key, val string) error {
+		header.Set(key, []byte(val))
+		return nil
+	})
+}
@@ -0,0 +1,55 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tracing provides context propagation and span creation for workflow and activity
+// execution, backed by either OpenTracing or OpenTelemetry. Register the returned
+// ContextPropagator on ClientOptions/WorkerOptions and the returned WorkflowInterceptorFactory on
+// WorkerOptions to get a child span around every ExecuteActivity and ExecuteChildWorkflow call.
+//
+// Those spans are currently linked to one another (every call in a given workflow run shares one
+// root span for that run) but NOT to the span active in the caller's context.Context: that would
+// require stashing the propagated parent span on the workflow Context, which has no
+// Context.WithValue equivalent in this build to stash it on. They are also not linked to the span
+// the activity worker itself starts, since nothing here injects the new span into the outgoing
+// header for the activity worker to extract - see the doc comments on tracingWorkflowInterceptor
+// and its ExecuteActivity/ExecuteChildWorkflow methods (internal/tracing_interceptor.go) for what
+// is and isn't connected.
+package tracing
+
+import (
+	"go.temporal.io/temporal/internal"
+)
+
+// NewContextPropagator returns a ContextPropagator that carries the span active in the caller's
+// context.Context across the client/worker boundary using tracer.
+func NewContextPropagator(tracer internal.Tracer) internal.ContextPropagator {
+	return internal.NewTracingContextPropagator(tracer)
+}
+
+// NewWorkflowInterceptorFactory returns a WorkflowInterceptorFactory that starts a child span,
+// using tracer, around every ExecuteActivity and ExecuteChildWorkflow call made by a workflow,
+// skipping span creation entirely while the workflow is replaying.
+func NewWorkflowInterceptorFactory(tracer internal.Tracer) internal.WorkflowInterceptorFactory {
+	return internal.NewTracingInterceptorFactory(tracer)
+}